@@ -0,0 +1,53 @@
+package alert
+
+// channel is an alert channel that can be enabled in the config
+type channel struct {
+	name        string // config name, as used in escalation_channels
+	label       string // display name used in errors
+	enabled     bool
+	minSeverity string
+	send        func(a Alert) error
+}
+
+// channels returns every alert channel, in the order alerts are sent
+func (m *Manager) channels() []channel {
+	alerts := m.config.Alerts
+	return []channel{
+		{"telegram", "Telegram", alerts.Telegram.Enabled, alerts.Telegram.MinSeverity, m.sendTelegramAlert},
+		{"discord", "Discord", alerts.Discord.Enabled, alerts.Discord.MinSeverity, m.sendDiscordAlert},
+		{"twilio", "Twilio", alerts.Twilio.Enabled, alerts.Twilio.MinSeverity, m.sendTwilioAlert},
+		{"slack", "Slack", alerts.Slack.Enabled, alerts.Slack.MinSeverity, func(a Alert) error { return m.sendSlackAlert(a.Message) }},
+		{"webhook", "Webhook", alerts.Webhook.Enabled, alerts.Webhook.MinSeverity, m.sendWebhookAlert},
+		{"pushover", "Pushover", alerts.Pushover.Enabled, alerts.Pushover.MinSeverity, m.sendPushoverAlert},
+		{"teams", "Teams", alerts.Teams.Enabled, alerts.Teams.MinSeverity, m.sendTeamsAlert},
+		{"sns", "SNS", alerts.SNS.Enabled, alerts.SNS.MinSeverity, m.sendSNSAlert},
+		{"pagerduty", "PagerDuty", alerts.PagerDuty.Enabled, alerts.PagerDuty.MinSeverity, m.sendPagerDutyAlert},
+		{"webex", "Webex", alerts.Webex.Enabled, alerts.Webex.MinSeverity, m.sendWebexAlert},
+		{"xmpp", "XMPP", alerts.XMPP.Enabled, alerts.XMPP.MinSeverity, m.sendXMPPAlert},
+		{"mqtt", "MQTT", alerts.MQTT.Enabled, alerts.MQTT.MinSeverity, m.sendMQTTAlert},
+		{"kafka", "Kafka", alerts.Kafka.Enabled, alerts.Kafka.MinSeverity, m.sendKafkaAlert},
+		{"nats", "NATS", alerts.NATS.Enabled, alerts.NATS.MinSeverity, m.sendNATSAlert},
+		{"opsgenie", "Opsgenie", alerts.Opsgenie.Enabled, alerts.Opsgenie.MinSeverity, m.sendOpsgenieAlert},
+	}
+}
+
+// channel returns the alert channel with the given config name
+func (m *Manager) channel(name string) (channel, bool) {
+	for _, ch := range m.channels() {
+		if ch.name == name {
+			return ch, true
+		}
+	}
+	return channel{}, false
+}
+
+// EnabledChannels returns the config names of the enabled alert channels
+func (m *Manager) EnabledChannels() []string {
+	var names []string
+	for _, ch := range m.channels() {
+		if ch.enabled {
+			names = append(names, ch.name)
+		}
+	}
+	return names
+}
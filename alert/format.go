@@ -0,0 +1,145 @@
+package alert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary is the part of a node status rendered by the compact formats
+type Summary struct {
+	Node          string // node name with its label
+	LocalHeight   uint64
+	NetworkHeight uint64
+	BlocksBehind  int64
+	Peers         int
+
+	// Readings of the last checks, oldest first and ending with this one.
+	// Empty without earlier checks.
+	HeightDiffTrend []int64
+	PeerTrend       []int
+}
+
+// Trend returns a line with the recent readings, e.g. "Trend over the last
+// 3 checks: diff 12 → 25 → 42, peers 8 → 6 → 3", or an empty string
+// without earlier checks
+func (s *Summary) Trend() string {
+	if len(s.HeightDiffTrend) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("Trend over the last %d checks: diff %s, peers %s",
+		len(s.HeightDiffTrend), joinReadings(s.HeightDiffTrend), joinReadings(s.PeerTrend))
+}
+
+// joinReadings renders readings as "12 → 25 → 42"
+func joinReadings[T int | int64](readings []T) string {
+	parts := make([]string, len(readings))
+	for i, reading := range readings {
+		parts[i] = fmt.Sprint(reading)
+	}
+	return strings.Join(parts, " → ")
+}
+
+// direction describes how readings moved from the first to the last for
+// the compact formats, as in " and rising". It is empty when they did not
+// change or without earlier readings.
+func direction[T int | int64](readings []T) string {
+	if len(readings) < 2 {
+		return ""
+	}
+	first, last := readings[0], readings[len(readings)-1]
+	switch {
+	case last > first:
+		return " and rising"
+	case last < first:
+		return " and falling"
+	default:
+		return ""
+	}
+}
+
+// Issue is one unhealthy category of a problem alert, rendered as its
+// own section of the message
+type Issue struct {
+	Category string // alert category, e.g. "sync"
+	Severity Severity
+	Summary  string   // short description for the summary line, e.g. "sync lag"
+	Name     string   // section heading, e.g. "Sync Issue"
+	Detail   string   // what is wrong
+	Context  []string // supporting values, one per line
+}
+
+// Headline returns the first line of the issue's section
+func (i Issue) Headline() string {
+	return fmt.Sprintf("❌ %s: %s", i.Name, i.Detail)
+}
+
+// Text renders the issue as a plain text section
+func (i Issue) Text() string {
+	text := i.Headline() + "\n"
+	for _, line := range i.Context {
+		text += "   " + line + "\n"
+	}
+	return text + "\n"
+}
+
+// IssueSummary returns a one-line summary of the issues, e.g.
+// "2 issues: sync lag, low peers"
+func IssueSummary(issues []Issue) string {
+	summaries := make([]string, len(issues))
+	for i, issue := range issues {
+		summaries[i] = issue.Summary
+	}
+
+	noun := "issues"
+	if len(issues) == 1 {
+		noun = "issue"
+	}
+	return fmt.Sprintf("%d %s: %s", len(issues), noun, strings.Join(summaries, ", "))
+}
+
+// shortMessage renders an alert as a single plain line for channels where
+// length matters, such as SMS, e.g. "default UNHEALTHY: 42 behind, 3 peers (sync lag, low peers)"
+func shortMessage(a Alert) string {
+	if a.Kind == KindTest {
+		return "Celestia Watchtower test alert: your SMS alerts are working"
+	}
+
+	// Alerts without a status, such as a lost connection, keep their headline
+	if a.Summary == nil {
+		headline, _, _ := strings.Cut(strings.TrimSpace(a.Message), "\n")
+		return plainText(headline)
+	}
+
+	state := "UNHEALTHY"
+	switch {
+	case a.Kind == KindRecovery:
+		state = "RECOVERED"
+	case a.Escalated:
+		state = "ESCALATED"
+	}
+
+	// The trend is a word, arrows would force SMS into a shorter encoding
+	message := fmt.Sprintf("%s %s: %d behind%s, %d peers%s", a.Summary.Node, state,
+		a.Summary.BlocksBehind, direction(a.Summary.HeightDiffTrend), a.Summary.Peers, direction(a.Summary.PeerTrend))
+	if len(a.Issues) > 0 {
+		_, summary, _ := strings.Cut(IssueSummary(a.Issues), ": ")
+		message += fmt.Sprintf(" (%s)", summary)
+	} else if a.Kind == KindProblem && len(a.Categories) > 0 {
+		message += fmt.Sprintf(" (%s)", strings.Join(a.Categories, ", "))
+	}
+	return message
+}
+
+// plainText drops emoji and other symbols, which force SMS into a
+// shorter encoding, and collapses the spaces they leave
+func plainText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		// Emoji, dingbats and variation selectors
+		if r >= 0x2190 && r <= 0x2BFF || r >= 0xFE00 && r <= 0xFE0F || r >= 0x1F000 {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,135 @@
+package alert
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// HistoryEntry records an alert and the outcome of delivering it
+type HistoryEntry struct {
+	Timestamp  time.Time  `json:"timestamp"`
+	Kind       Kind       `json:"kind"`
+	Severity   string     `json:"severity,omitempty"`
+	Node       string     `json:"node,omitempty"`
+	Categories []string   `json:"categories,omitempty"`
+	Message    string     `json:"message"`
+	Silenced   string     `json:"silenced,omitempty"` // why delivery was held back
+	Deliveries []Delivery `json:"deliveries,omitempty"`
+}
+
+// Delivery is the result of sending an alert to one channel
+type Delivery struct {
+	Channel string `json:"channel"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Failed reports whether any channel failed to deliver the alert
+func (h HistoryEntry) Failed() bool {
+	for _, d := range h.Deliveries {
+		if d.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// recordHistory appends an alert to the history file, rotating the file
+// to alerts.jsonl.1 once it reaches the configured size
+func (m *Manager) recordHistory(a Alert, silenced string, deliveries []Delivery) error {
+	if !m.config.Alerts.History.Enabled {
+		return nil
+	}
+
+	historyFile, err := config.AlertHistoryFile()
+	if err != nil {
+		return err
+	}
+
+	entry := HistoryEntry{
+		Timestamp:  a.Timestamp,
+		Kind:       a.Kind,
+		Node:       a.Node,
+		Categories: a.Categories,
+		Message:    a.Message,
+		Silenced:   silenced,
+		Deliveries: deliveries,
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	if a.Severity > 0 {
+		entry.Severity = a.Severity.String()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert history entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(historyFile), 0755); err != nil {
+		return fmt.Errorf("failed to create alert history directory: %w", err)
+	}
+
+	maxSize := int64(m.config.Alerts.History.MaxSizeMB) * 1024 * 1024
+	if info, err := os.Stat(historyFile); err == nil && info.Size()+int64(len(line)) > maxSize {
+		if err := os.Rename(historyFile, historyFile+".1"); err != nil {
+			return fmt.Errorf("failed to rotate alert history file: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open alert history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write alert history file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadAlertHistory reads the recorded alerts, oldest first, including the
+// rotated file
+func LoadAlertHistory() ([]HistoryEntry, error) {
+	historyFile, err := config.AlertHistoryFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	for _, path := range []string{historyFile + ".1", historyFile} {
+		file, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read alert history file: %w", err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var entry HistoryEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				// Skip lines torn by a crash
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read alert history file: %w", err)
+		}
+	}
+
+	return entries, nil
+}
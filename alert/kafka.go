@@ -0,0 +1,190 @@
+package alert
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// Kafka delivery limits. Events are queued and produced in the background
+// so a broker outage never stalls the check loop.
+const (
+	kafkaQueueSize      = 100
+	kafkaEnqueueTimeout = time.Second
+	kafkaWriteTimeout   = 10 * time.Second
+)
+
+// kafkaEvent is the JSON document produced for each alert
+type kafkaEvent struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	Node       string      `json:"node,omitempty"`
+	Type       Kind        `json:"type"`
+	Severity   string      `json:"severity"`
+	Categories []string    `json:"categories,omitempty"`
+	Message    string      `json:"message"`
+	Status     interface{} `json:"status,omitempty"`
+}
+
+// kafkaProducer produces queued events to the topic in the background
+type kafkaProducer struct {
+	writer *kafka.Writer
+	queue  chan kafka.Message
+
+	mu       sync.Mutex
+	failures int   // events that failed to deliver since last reported
+	lastErr  error // most recent delivery error
+}
+
+// sendKafkaAlert queues an alert event for production to the Kafka topic.
+// Earlier events that failed to deliver are reported here, since
+// production happens after the alert has been handed over.
+func (m *Manager) sendKafkaAlert(a Alert) error {
+	producer, err := m.getKafkaProducer()
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(kafkaEvent{
+		Timestamp:  a.Timestamp,
+		Node:       a.Node,
+		Type:       a.Kind,
+		Severity:   a.Severity.String(),
+		Categories: a.Categories,
+		Message:    a.Message,
+		Status:     a.Status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Kafka event: %w", err)
+	}
+
+	msg := kafka.Message{Key: []byte(a.Node), Value: value}
+
+	// Test alerts are produced directly so test-alert reports broker problems
+	if a.Kind == KindTest {
+		ctx, cancel := context.WithTimeout(context.Background(), kafkaWriteTimeout)
+		defer cancel()
+		if err := producer.writer.WriteMessages(ctx, msg); err != nil {
+			return fmt.Errorf("failed to produce Kafka event: %w", err)
+		}
+		return nil
+	}
+
+	failures := producer.takeFailures()
+	if err := producer.enqueue(msg); err != nil {
+		if failures != nil {
+			return fmt.Errorf("%v; %w", failures, err)
+		}
+		return err
+	}
+
+	return failures
+}
+
+// getKafkaProducer starts the background producer on first use
+func (m *Manager) getKafkaProducer() (*kafkaProducer, error) {
+	if m.kafkaProducer != nil {
+		return m.kafkaProducer, nil
+	}
+
+	cfg := m.config.Alerts.Kafka
+	if len(cfg.Brokers) == 0 || cfg.Topic == "" {
+		return nil, fmt.Errorf("Kafka brokers or topic not configured")
+	}
+
+	var mechanism sasl.Mechanism
+	switch cfg.SASLMechanism {
+	case "":
+	case "plain":
+		mechanism = plain.Mechanism{Username: cfg.Username, Password: cfg.Password}
+	case "scram-sha-256", "scram-sha-512":
+		algorithm := scram.SHA256
+		if cfg.SASLMechanism == "scram-sha-512" {
+			algorithm = scram.SHA512
+		}
+		var err error
+		mechanism, err = scram.Mechanism(algorithm, cfg.Username, cfg.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Kafka SASL: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Kafka SASL mechanism %q", cfg.SASLMechanism)
+	}
+
+	transport := &kafka.Transport{SASL: mechanism}
+	if cfg.TLS {
+		transport.TLS = &tls.Config{}
+	}
+
+	producer := &kafkaProducer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			WriteTimeout: kafkaWriteTimeout,
+			Transport:    transport,
+		},
+		queue: make(chan kafka.Message, kafkaQueueSize),
+	}
+	go producer.run()
+
+	m.kafkaProducer = producer
+	return producer, nil
+}
+
+// enqueue hands a message to the background producer, dropping it if the
+// queue stays full because the brokers are not keeping up
+func (p *kafkaProducer) enqueue(msg kafka.Message) error {
+	select {
+	case p.queue <- msg:
+		return nil
+	case <-time.After(kafkaEnqueueTimeout):
+		return fmt.Errorf("Kafka queue full, event dropped")
+	}
+}
+
+// run produces queued messages until the queue is closed
+func (p *kafkaProducer) run() {
+	for msg := range p.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), kafkaWriteTimeout)
+		err := p.writer.WriteMessages(ctx, msg)
+		cancel()
+
+		if err != nil {
+			p.mu.Lock()
+			p.failures++
+			p.lastErr = err
+			p.mu.Unlock()
+		}
+	}
+	p.writer.Close()
+}
+
+// close stops accepting events; queued events are still produced
+func (p *kafkaProducer) close() {
+	close(p.queue)
+}
+
+// takeFailures returns an error describing deliveries that failed since the
+// last call, or nil if there were none
+func (p *kafkaProducer) takeFailures() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("%d earlier event(s) failed to deliver: %w", p.failures, p.lastErr)
+	p.failures = 0
+	p.lastErr = nil
+	return err
+}
@@ -0,0 +1,102 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// ErrSilenced is returned by Send when a maintenance window or an ad-hoc
+// silence holds back delivery
+var ErrSilenced = errors.New("alerts silenced")
+
+// Silence is an ad-hoc maintenance window written by the silence command
+type Silence struct {
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// silencedBy describes the maintenance window or silence covering the given
+// time, or returns an empty string if alerts can be delivered
+func (m *Manager) silencedBy(now time.Time) string {
+	for _, window := range m.config.Alerts.MaintenanceWindows {
+		if window.Active(now) {
+			return fmt.Sprintf("maintenance window %q", window.Name)
+		}
+	}
+
+	// A broken silence file must not stop alerts
+	silence, err := LoadSilence()
+	if err != nil || silence == nil || !now.Before(silence.Until) {
+		return ""
+	}
+	if silence.Reason != "" {
+		return fmt.Sprintf("silence until %s (%s)", m.config.FormatTime(silence.Until), silence.Reason)
+	}
+	return fmt.Sprintf("silence until %s", m.config.FormatTime(silence.Until))
+}
+
+// LoadSilence reads the ad-hoc silence, returning nil if there is none
+func LoadSilence() (*Silence, error) {
+	silenceFile, err := config.SilenceFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(silenceFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read silence file: %w", err)
+	}
+
+	var silence Silence
+	if err := json.Unmarshal(data, &silence); err != nil {
+		return nil, fmt.Errorf("failed to parse silence file: %w", err)
+	}
+
+	return &silence, nil
+}
+
+// SaveSilence silences alerts until the given silence expires
+func SaveSilence(silence Silence) error {
+	silenceFile, err := config.SilenceFile()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(silenceFile), 0755); err != nil {
+		return fmt.Errorf("failed to create silence directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(silence, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal silence: %w", err)
+	}
+
+	if err := os.WriteFile(silenceFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write silence file: %w", err)
+	}
+
+	return nil
+}
+
+// ClearSilence removes the ad-hoc silence
+func ClearSilence() error {
+	silenceFile, err := config.SilenceFile()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(silenceFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove silence file: %w", err)
+	}
+
+	return nil
+}
@@ -2,53 +2,265 @@ package alert
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/21state/celestia-watchtower/config"
 )
 
 // Manager handles sending alerts to configured channels
 type Manager struct {
-	config *config.Config
+	config          *config.Config
+	webhookTemplate *template.Template
+	snsClient       snsClient
+	mqttClient      mqttClient
+	kafkaProducer   *kafkaProducer
+	natsConn        natsConn
+	httpClient      *http.Client
+
+	// testStatus is the sample node status carried by test alerts
+	testStatus interface{}
+
+	// decodeStatus turns the status of a queued alert back into its type
+	decodeStatus func(data []byte) (interface{}, error)
+
+	// mu serializes deliveries, which share the lazily created clients,
+	// between the check loop and the outbox sender
+	mu sync.Mutex
+}
+
+// Kind describes why an alert is being sent
+type Kind string
+
+// Alert kinds
+const (
+	KindProblem  Kind = "problem"
+	KindRecovery Kind = "recovery"
+	KindTest     Kind = "test"
+	KindDigest   Kind = "digest"
+	KindNotice   Kind = "notice" // an event such as a restart, with nothing to resolve
+)
+
+// Fact is a labelled value describing the node, used by channels that
+// render structured messages
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// Alert is a single notification delivered to the configured channels
+type Alert struct {
+	Kind       Kind
+	Severity   Severity
+	Node       string   // name of the node the alert is about
+	Categories []string // affected categories, e.g. "sync" or "network"
+	Issues     []Issue  // what is wrong in each category, empty except for problem alerts
+	Message    string
+	Timestamp  time.Time
+	Status     interface{} // node status snapshot, a sample for test alerts, nil for digests and unreachable nodes
+	Facts      []Fact      // health summary, empty for test alerts
+	Summary    *Summary    // heights and peers for the compact formats, nil without a status
+	Escalated  bool        // node has been unhealthy past the escalation threshold
+}
+
+// NewManager creates a new alert manager whose HTTP requests time out
+// after alerts.http_timeout_seconds
+func NewManager(cfg *config.Config) (*Manager, error) {
+	timeout := time.Duration(cfg.Alerts.HTTPTimeoutSeconds) * time.Second
+	return NewManagerWithClient(cfg, &http.Client{Timeout: timeout})
+}
+
+// NewManagerWithClient creates a new alert manager that sends the HTTP
+// based channels through the given client
+func NewManagerWithClient(cfg *config.Config, client *http.Client) (*Manager, error) {
+	m := &Manager{
+		config:     cfg,
+		httpClient: client,
+	}
+
+	// Validate the per-channel severity thresholds
+	for _, ch := range m.channels() {
+		if _, err := ParseSeverity(ch.minSeverity); err != nil {
+			return nil, fmt.Errorf("invalid min_severity for %s: %w", ch.name, err)
+		}
+	}
+
+	for _, name := range cfg.Alerts.EscalationChannels {
+		if _, ok := m.channel(name); !ok {
+			return nil, fmt.Errorf("unknown escalation channel %q", name)
+		}
+	}
+
+	for _, name := range cfg.Alerts.Digest.Channels {
+		if _, ok := m.channel(name); !ok {
+			return nil, fmt.Errorf("unknown digest channel %q", name)
+		}
+	}
+
+	if cfg.Alerts.MQTT.QoS > 2 {
+		return nil, fmt.Errorf("invalid MQTT qos %d: must be 0, 1 or 2", cfg.Alerts.MQTT.QoS)
+	}
+
+	switch cfg.Alerts.Twilio.Channel {
+	case "", "sms", "whatsapp", "both":
+	default:
+		return nil, fmt.Errorf("invalid Twilio channel %q: must be sms, whatsapp or both", cfg.Alerts.Twilio.Channel)
+	}
+
+	switch strings.ToLower(cfg.Alerts.Telegram.ParseMode) {
+	case "", "markdown", "markdownv2", "html", "plain", "none":
+	default:
+		return nil, fmt.Errorf("invalid Telegram parse_mode %q: must be markdown, markdownv2, html or none", cfg.Alerts.Telegram.ParseMode)
+	}
+
+	switch cfg.Alerts.Opsgenie.Region {
+	case "", "us", "eu":
+	default:
+		return nil, fmt.Errorf("invalid Opsgenie region %q: must be us or eu", cfg.Alerts.Opsgenie.Region)
+	}
+
+	// Parse the webhook template up front so a bad template fails fast
+	if cfg.Alerts.Webhook.Enabled {
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+			"json": templateJSON,
+		}).Parse(cfg.Alerts.Webhook.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook body template: %w", err)
+		}
+		m.webhookTemplate = tmpl
+	}
+
+	return m, nil
 }
 
-// NewManager creates a new alert manager
-func NewManager(cfg *config.Config) *Manager {
-	return &Manager{
-		config: cfg,
+// Close disconnects the long-lived MQTT, Kafka and NATS clients. A Manager
+// used after Close connects again on demand.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.mqttClient != nil {
+		m.mqttClient.Disconnect(250)
+		m.mqttClient = nil
+	}
+	if m.kafkaProducer != nil {
+		m.kafkaProducer.close()
+		m.kafkaProducer = nil
+	}
+	if m.natsConn != nil {
+		m.natsConn.Close()
+		m.natsConn = nil
+	}
+}
+
+// templateJSON encodes a value as JSON for use inside templates
+func templateJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
 	}
+	return string(data), nil
+}
+
+// SendAlert sends a plain message to all channels accepting the severity
+func (m *Manager) SendAlert(severity Severity, message string) error {
+	return m.Send(Alert{Kind: KindProblem, Severity: severity, Message: message, Timestamp: time.Now()})
 }
 
-// SendAlert sends an alert to all configured channels
-func (m *Manager) SendAlert(message string) error {
+// routes reports whether a channel with the given minimum severity should
+// receive the alert. Test alerts always go to every enabled channel,
+// digests only to the digest channels and escalated alerts always go to
+// the escalation channels.
+func (m *Manager) routes(channel, minSeverity string, a Alert) bool {
+	if a.Kind == KindTest {
+		return true
+	}
+
+	// Incident channels would open an incident that no recovery resolves
+	if a.Kind == KindNotice && (channel == "pagerduty" || channel == "opsgenie") {
+		return false
+	}
+
+	digest := m.config.Alerts.Digest
+	if a.Kind == KindDigest {
+		return slices.Contains(digest.Channels, channel)
+	}
+	if digest.Exclusive && slices.Contains(digest.Channels, channel) {
+		return false
+	}
+
+	if a.Escalated && slices.Contains(m.config.Alerts.EscalationChannels, channel) {
+		return true
+	}
+
+	min, err := ParseSeverity(minSeverity)
+	if err != nil {
+		min = SeverityInfo
+	}
+	return a.Severity >= min
+}
+
+// Send sends an alert to all configured channels. Deliveries that fail are
+// queued in the outbox and retried by DrainOutbox.
+func (m *Manager) Send(a Alert) error {
 	if !m.config.Alerts.Enabled {
 		return nil
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Test alerts still go out so channels can be checked during maintenance
+	if a.Kind != KindTest {
+		if reason := m.silencedBy(time.Now()); reason != "" {
+			// The silence is what matters to the caller, not the history
+			_ = m.recordHistory(a, reason, nil)
+			return fmt.Errorf("%w by %s", ErrSilenced, reason)
+		}
+	}
+
 	var errors []string
+	var failed []string
+	var deliveries []Delivery
 
-	// Send Telegram alert
-	if m.config.Alerts.Telegram.Enabled {
-		if err := m.sendTelegramAlert(message); err != nil {
-			errors = append(errors, fmt.Sprintf("Telegram: %v", err))
+	var routed []channel
+	for _, ch := range m.channels() {
+		if ch.enabled && m.routes(ch.name, ch.minSeverity, a) {
+			routed = append(routed, ch)
 		}
 	}
 
-	// Send Discord alert
-	if m.config.Alerts.Discord.Enabled {
-		if err := m.sendDiscordAlert(message); err != nil {
-			errors = append(errors, fmt.Sprintf("Discord: %v", err))
+	for i, err := range m.deliverAll(routed, a) {
+		ch := routed[i]
+		delivery := Delivery{Channel: ch.name}
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", ch.label, err))
+			failed = append(failed, ch.name)
+			delivery.Error = err.Error()
 		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := m.recordHistory(a, "", deliveries); err != nil {
+		errors = append(errors, fmt.Sprintf("History: %v", err))
 	}
 
-	// Send Twilio SMS alert
-	if m.config.Alerts.Twilio.Enabled {
-		if err := m.sendTwilioAlert(message); err != nil {
-			errors = append(errors, fmt.Sprintf("Twilio: %v", err))
+	// Test alerts report problems directly and are not retried
+	if len(failed) > 0 && a.Kind != KindTest && m.config.Alerts.Outbox.Enabled {
+		if err := m.enqueueOutbox(a, failed); err != nil {
+			errors = append(errors, fmt.Sprintf("Outbox: %v", err))
 		}
 	}
 
@@ -59,41 +271,278 @@ func (m *Manager) SendAlert(message string) error {
 	return nil
 }
 
-// sendTelegramAlert sends an alert via Telegram
-func (m *Manager) sendTelegramAlert(message string) error {
+// Default API base URLs, overridable in the config
+const (
+	telegramAPIURL = "https://api.telegram.org"
+	twilioAPIURL   = "https://api.twilio.com"
+)
+
+// baseURL returns the configured API base URL, or the default if none is set
+func baseURL(configured, fallback string) string {
+	if configured == "" {
+		return fallback
+	}
+	return strings.TrimSuffix(configured, "/")
+}
+
+// sendTelegramAlert sends an alert via Telegram to every configured chat
+func (m *Manager) sendTelegramAlert(a Alert) error {
 	botToken := m.config.Alerts.Telegram.BotToken
-	chatID := m.config.Alerts.Telegram.ChatID
+	chatIDs := m.config.Alerts.Telegram.ChatIDs
 
-	if botToken == "" || chatID == "" {
+	if botToken == "" || len(chatIDs) == 0 {
 		return fmt.Errorf("Telegram bot token or chat ID not configured")
 	}
 
+	// Long messages go out as several messages, in order
+	parts := telegramParts(m.config.Alerts.Telegram.ParseMode, a.Message, a.Issues)
+
+	var errs []recipientError
+	for _, chatID := range chatIDs {
+		for i, part := range parts {
+			// The button goes below the last part
+			keyboard := ""
+			if i == len(parts)-1 {
+				keyboard = m.telegramKeyboard(a)
+			}
+			if err := m.sendTelegramMessage(botToken, chatID, part, a.Issues, keyboard); err != nil {
+				if len(parts) > 1 {
+					err = fmt.Errorf("part %d of %d: %w", i+1, len(parts), err)
+				}
+				errs = append(errs, recipientError{recipient: chatID, err: err})
+				break
+			}
+		}
+	}
+
+	return joinRecipientErrors(len(chatIDs), errs)
+}
+
+// telegramMessageLimit is the maximum length of a Telegram message,
+// counted in UTF-16 code units
+const telegramMessageLimit = 4096
+
+// telegramParts splits a message into parts that fit in a Telegram message
+// once formatted for the parse mode. MarkdownV2 escapes most punctuation,
+// so the formatted text can be much longer than the message.
+func telegramParts(mode, message string, issues []Issue) []string {
+	length := func(s string) int {
+		text, _ := telegramFormat(mode, s, issues)
+		return utf16Len(text)
+	}
+
+	var parts []string
+	for _, part := range splitMessage(message, telegramMessageLimit, length) {
+		parts = append(parts, fitMessage(part, telegramMessageLimit, length)...)
+	}
+	return parts
+}
+
+// fitMessage splits a part again until each piece measures at most limit
+// as a whole. Lines are measured on their own when splitting, and a line
+// can format differently in the context of the lines before it.
+func fitMessage(part string, limit int, length func(string) int) []string {
+	n := length(part)
+	if n <= limit {
+		return []string{part}
+	}
+
+	// Shrink the limit by how much the part overshot, keeping room for any
+	// single character so each piece is shorter than the part
+	var parts []string
+	for _, piece := range splitMessage(part, max(limit*utf16Len(part)/n, 2), utf16Len) {
+		parts = append(parts, fitMessage(piece, limit, length)...)
+	}
+	return parts
+}
+
+// splitMessage splits a message on line boundaries into parts no longer
+// than limit, measuring each line with length. Lines longer than the limit
+// are split between characters.
+func splitMessage(message string, limit int, length func(string) int) []string {
+	if length(message) <= limit {
+		return []string{message}
+	}
+
+	var parts []string
+	var part strings.Builder
+	partLen := 0
+	flush := func() {
+		if part.Len() > 0 {
+			parts = append(parts, part.String())
+			part.Reset()
+			partLen = 0
+		}
+	}
+
+	for _, line := range strings.SplitAfter(message, "\n") {
+		lineLen := length(line)
+		if partLen+lineLen > limit {
+			flush()
+		}
+		for lineLen > limit {
+			// Cut the line at the last character that fits
+			n, cut := 0, 0
+			for i, r := range line {
+				runeLen := length(string(r))
+				if n+runeLen > limit {
+					cut = i
+					break
+				}
+				n += runeLen
+			}
+			parts = append(parts, line[:cut])
+			line = line[cut:]
+			lineLen -= n
+		}
+		part.WriteString(line)
+		partLen += lineLen
+	}
+	flush()
+
+	return parts
+}
+
+// utf16Len returns the length of s in UTF-16 code units
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16RuneLen(r)
+	}
+	return n
+}
+
+// utf16RuneLen returns the number of UTF-16 code units encoding r, two
+// for characters outside the Basic Multilingual Plane such as emoji
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// sendTelegramMessage sends a message to a single Telegram chat
+func (m *Manager) sendTelegramMessage(botToken, chatID, message string, issues []Issue, keyboard string) error {
 	// Prepare API URL
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+	apiURL := fmt.Sprintf("%s/bot%s/sendMessage", baseURL(m.config.Alerts.Telegram.APIURL, telegramAPIURL), botToken)
 
 	// Prepare request body
 	data := url.Values{}
+	text, parseMode := telegramFormat(m.config.Alerts.Telegram.ParseMode, message, issues)
 	data.Set("chat_id", chatID)
-	data.Set("text", message)
-	data.Set("parse_mode", "Markdown")
+	data.Set("text", text)
+	if parseMode != "" {
+		data.Set("parse_mode", parseMode)
+	}
+	if threadID := m.config.Alerts.Telegram.ThreadID; threadID != 0 {
+		data.Set("message_thread_id", strconv.Itoa(threadID))
+	}
+	if keyboard != "" {
+		data.Set("reply_markup", keyboard)
+	}
 
 	// Send request
-	resp, err := http.PostForm(apiURL, data)
+	resp, description, err := m.postTelegram(apiURL, data)
 	if err != nil {
-		return fmt.Errorf("failed to send Telegram alert: %w", err)
+		return err
+	}
+
+	// A message the API cannot parse is sent again as plain text
+	if resp.StatusCode == http.StatusBadRequest && data.Has("parse_mode") && strings.Contains(description, "can't parse entities") {
+		data.Del("parse_mode")
+		data.Set("text", message)
+		if resp, description, err = m.postTelegram(apiURL, data); err != nil {
+			return err
+		}
+	}
+
+	// Chats without topics reject the thread ID, so retry in the main chat.
+	// Other rejections, such as an unknown chat, would fail there too.
+	if resp.StatusCode == http.StatusBadRequest && data.Has("message_thread_id") && strings.Contains(description, "message thread") {
+		data.Del("message_thread_id")
+		if resp, description, err = m.postTelegram(apiURL, data); err != nil {
+			return err
+		}
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Telegram API returned non-OK status: %s", resp.Status)
+		if description != "" {
+			return statusErrorf(resp.StatusCode, "Telegram API returned non-OK status: %s: %s", resp.Status, description)
+		}
+		return statusErrorf(resp.StatusCode, "Telegram API returned non-OK status: %s", resp.Status)
 	}
 
 	return nil
 }
 
+// postTelegram posts a form to the Telegram API and returns the response
+// with the error description from its body, if any
+func (m *Manager) postTelegram(apiURL string, data url.Values) (*http.Response, string, error) {
+	resp, err := m.httpClient.PostForm(apiURL, data)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to send Telegram alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Description string `json:"description"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&body)
+	}
+	return resp, body.Description, nil
+}
+
+// telegramMarkdownEscaper escapes every character reserved by MarkdownV2
+var telegramMarkdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`,
+	"=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// telegramHTMLEscaper escapes the characters Telegram's HTML mode requires
+var telegramHTMLEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// telegramFormat escapes a plain text message for the configured parse mode
+// and returns it with the parse_mode to send, empty for plain text. The
+// sections of the issues get a bold heading and a bullet per value.
+func telegramFormat(mode, message string, issues []Issue) (string, string) {
+	escape, bold, parseMode := telegramMarkdownEscaper.Replace, "*%s*", "MarkdownV2"
+	switch strings.ToLower(mode) {
+	case "html":
+		escape, bold, parseMode = telegramHTMLEscaper.Replace, "<b>%s</b>", "HTML"
+	case "plain", "none":
+		return message, ""
+	}
+
+	headlines := make(map[string]Issue, len(issues))
+	for _, issue := range issues {
+		headlines[issue.Headline()] = issue
+	}
+
+	lines := strings.Split(message, "\n")
+	inIssue := false
+	for i, line := range lines {
+		if issue, ok := headlines[line]; ok {
+			lines[i] = "❌ " + fmt.Sprintf(bold, escape(issue.Name)) + escape(": "+issue.Detail)
+			inIssue = true
+			continue
+		}
+		if inIssue && strings.HasPrefix(line, "   ") {
+			lines[i] = "  • " + escape(strings.TrimSpace(line))
+			continue
+		}
+		inIssue = false
+		lines[i] = escape(line)
+	}
+	return strings.Join(lines, "\n"), parseMode
+}
+
 // sendDiscordAlert sends an alert via Discord webhook
-func (m *Manager) sendDiscordAlert(message string) error {
+func (m *Manager) sendDiscordAlert(a Alert) error {
 	webhook := m.config.Alerts.Discord.Webhook
+	roles := m.config.Alerts.Discord.MentionRoles
+	users := m.config.Alerts.Discord.MentionUsers
 
 	if webhook == "" {
 		return fmt.Errorf("Discord webhook not configured")
@@ -101,7 +550,28 @@ func (m *Manager) sendDiscordAlert(message string) error {
 
 	// Prepare request body
 	payload := map[string]interface{}{
-		"content": message,
+		"content": a.Message,
+	}
+	if m.config.Alerts.Discord.Embeds {
+		payload["content"] = ""
+		payload["embeds"] = []interface{}{discordEmbed(a)}
+	}
+
+	// Only unhealthy alerts ping anyone
+	if a.Kind == KindProblem && len(roles)+len(users) > 0 {
+		var mentions []string
+		for _, role := range roles {
+			mentions = append(mentions, fmt.Sprintf("<@&%s>", role))
+		}
+		for _, user := range users {
+			mentions = append(mentions, fmt.Sprintf("<@%s>", user))
+		}
+
+		payload["content"] = strings.Join(mentions, " ") + "\n" + payload["content"].(string)
+		payload["allowed_mentions"] = map[string]interface{}{
+			"roles": roles,
+			"users": users,
+		}
 	}
 
 	jsonPayload, err := json.Marshal(payload)
@@ -110,37 +580,143 @@ func (m *Manager) sendDiscordAlert(message string) error {
 	}
 
 	// Send request
-	resp, err := http.Post(webhook, "application/json", bytes.NewBuffer(jsonPayload))
+	resp, err := m.httpClient.Post(webhook, "application/json", bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to send Discord alert: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Discord API returned non-OK status: %s", resp.Status)
+		return statusErrorf(resp.StatusCode, "Discord API returned non-OK status: %s", resp.Status)
 	}
 
 	return nil
 }
 
-// sendTwilioAlert sends an alert via Twilio SMS
-func (m *Manager) sendTwilioAlert(message string) error {
+// Discord embed colors
+const (
+	discordColorProblem  = 0xE81123
+	discordColorRecovery = 0x2EB886
+	discordColorTest     = 0x0078D7
+)
+
+// discordDescriptionLimit is the maximum length of an embed description
+const discordDescriptionLimit = 4096
+
+// discordEmbed renders an alert as a Discord embed, color coded by kind,
+// with a field per issue and the health summary as inline fields
+func discordEmbed(a Alert) map[string]interface{} {
+	title, color := "🔴 Unhealthy: "+a.Node, discordColorProblem
+	switch a.Kind {
+	case KindRecovery:
+		title, color = "🟢 Recovered: "+a.Node, discordColorRecovery
+	case KindTest:
+		title, color = "Celestia Watchtower Test", discordColorTest
+	case KindDigest:
+		title, color = "📊 Celestia Watchtower Digest", discordColorTest
+	case KindNotice:
+		title, color = "🔔 Notice: "+a.Node, discordColorTest
+	}
+	if a.Node == "" && a.Kind != KindTest && a.Kind != KindDigest {
+		title = "Celestia Node Alert"
+	}
+
+	// Each issue gets its own field instead of a section of the description
+	description := a.Message
+	fields := make([]map[string]interface{}, 0, len(a.Issues)+len(a.Facts))
+	for _, issue := range a.Issues {
+		description = strings.Replace(description, issue.Text(), "", 1)
+		value := issue.Detail
+		if len(issue.Context) > 0 {
+			value += "\n" + strings.Join(issue.Context, "\n")
+		}
+		fields = append(fields, map[string]interface{}{"name": "❌ " + issue.Name, "value": value, "inline": false})
+	}
+	description = strings.TrimSpace(description)
+	if len(description) > discordDescriptionLimit {
+		description = description[:discordDescriptionLimit]
+	}
+
+	for _, fact := range a.Facts {
+		if fact.Name == "Node" || fact.Value == "" {
+			continue
+		}
+		fields = append(fields, map[string]interface{}{"name": fact.Name, "value": fact.Value, "inline": true})
+	}
+
+	embed := map[string]interface{}{
+		"title":       title,
+		"description": description,
+		"color":       color,
+		"fields":      fields,
+	}
+	if !a.Timestamp.IsZero() {
+		embed["timestamp"] = a.Timestamp.UTC().Format(time.RFC3339)
+	}
+	return embed
+}
+
+// sendTwilioAlert sends an alert via Twilio SMS to every configured number.
+// SMS get the compact single line form, WhatsApp the full message.
+func (m *Manager) sendTwilioAlert(a Alert) error {
 	accountSID := m.config.Alerts.Twilio.AccountSID
 	authToken := m.config.Alerts.Twilio.AuthToken
 	fromNumber := m.config.Alerts.Twilio.FromNumber
-	toNumber := m.config.Alerts.Twilio.ToNumber
+	toNumbers := m.config.Alerts.Twilio.ToNumbers
 
-	if accountSID == "" || authToken == "" || fromNumber == "" || toNumber == "" {
+	if accountSID == "" || authToken == "" || fromNumber == "" || len(toNumbers) == 0 {
 		return fmt.Errorf("Twilio credentials or phone numbers not configured")
 	}
 
+	sendSMS, sendWhatsApp := twilioTransports(m.config.Alerts.Twilio.Channel)
+
+	var errs []recipientError
+	recipients := 0
+
+	for _, toNumber := range toNumbers {
+		if sendSMS {
+			recipients++
+			if err := m.sendTwilioMessage(fromNumber, toNumber, shortMessage(a)); err != nil {
+				errs = append(errs, recipientError{recipient: "SMS " + toNumber, err: err})
+			}
+		}
+
+		// WhatsApp uses the same API with prefixed numbers
+		if sendWhatsApp {
+			recipients++
+			if err := m.sendTwilioMessage("whatsapp:"+fromNumber, "whatsapp:"+toNumber, a.Message); err != nil {
+				errs = append(errs, recipientError{recipient: "WhatsApp " + toNumber, err: err})
+			}
+		}
+	}
+
+	return joinRecipientErrors(recipients, errs)
+}
+
+// twilioTransports reports which Twilio transports the channel setting enables
+func twilioTransports(channel string) (sms, whatsapp bool) {
+	switch channel {
+	case "whatsapp":
+		return false, true
+	case "both":
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// sendTwilioMessage sends a single message through the Twilio Messages API
+func (m *Manager) sendTwilioMessage(from, to, message string) error {
+	accountSID := m.config.Alerts.Twilio.AccountSID
+	authToken := m.config.Alerts.Twilio.AuthToken
+
 	// Prepare API URL
-	apiURL := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", accountSID)
+	apiURL := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", baseURL(m.config.Alerts.Twilio.APIURL, twilioAPIURL), accountSID)
 
 	// Prepare request body
 	data := url.Values{}
-	data.Set("From", fromNumber)
-	data.Set("To", toNumber)
+	data.Set("From", from)
+	data.Set("To", to)
 	data.Set("Body", message)
 
 	// Create request
@@ -154,22 +730,479 @@ func (m *Manager) sendTwilioAlert(message string) error {
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := m.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send Twilio alert: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("Twilio API returned non-Created status: %s", resp.Status)
+		return statusErrorf(resp.StatusCode, "Twilio API returned non-Created status: %s", resp.Status)
 	}
 
 	return nil
 }
 
+// sendSlackAlert sends an alert via Slack incoming webhook
+func (m *Manager) sendSlackAlert(message string) error {
+	webhook := m.config.Alerts.Slack.WebhookURL
+
+	if webhook == "" {
+		return fmt.Errorf("Slack webhook URL not configured")
+	}
+
+	// Prepare request body
+	payload := map[string]interface{}{
+		"text": message,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack payload: %w", err)
+	}
+
+	// Send request
+	resp, err := m.httpClient.Post(webhook, "application/json", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to send Slack alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusErrorf(resp.StatusCode, "Slack API returned non-OK status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// sendWebhookAlert sends an alert to a generic webhook using the configured body template
+func (m *Manager) sendWebhookAlert(a Alert) error {
+	webhook := m.config.Alerts.Webhook
+
+	if webhook.URL == "" {
+		return fmt.Errorf("webhook URL not configured")
+	}
+
+	if m.webhookTemplate == nil {
+		return fmt.Errorf("webhook body template not loaded")
+	}
+
+	// Render request body
+	var body bytes.Buffer
+	if err := m.webhookTemplate.Execute(&body, a); err != nil {
+		return fmt.Errorf("failed to render webhook body: %w", err)
+	}
+
+	method := webhook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	// Create request
+	req, err := http.NewRequest(method, webhook.URL, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	// Set headers, custom headers may override the content type
+	contentType := webhook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range webhook.Headers {
+		req.Header.Set(key, value)
+	}
+
+	// Signatures are set last so custom headers cannot replace them
+	if webhook.Secret != "" {
+		timestamp := time.Now()
+		req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(webhook.Secret, timestamp, body.Bytes()))
+	}
+
+	// Send request
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return statusErrorf(resp.StatusCode, "webhook returned non-2xx status: %s: %s", resp.Status, strings.TrimSpace(string(snippet)))
+	}
+
+	return nil
+}
+
+// Headers of a signed webhook request
+const (
+	WebhookTimestampHeader = "X-Watchtower-Timestamp" // Unix time the request was signed at
+	WebhookSignatureHeader = "X-Watchtower-Signature" // "sha256=" followed by the hex HMAC
+)
+
+// SignWebhook returns the signature of a webhook request body sent at the
+// given time: "sha256=" and the hex HMAC-SHA256, keyed with the secret, of
+// the Unix timestamp, a dot and the body. Covering the timestamp lets
+// receivers reject replayed requests.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp.Unix())
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendPushoverAlert sends an alert via Pushover
+func (m *Manager) sendPushoverAlert(a Alert) error {
+	userKey := m.config.Alerts.Pushover.UserKey
+	appToken := m.config.Alerts.Pushover.AppToken
+
+	if userKey == "" || appToken == "" {
+		return fmt.Errorf("Pushover user key or app token not configured")
+	}
+
+	// Only unhealthy alerts use the configured priority
+	priority := 0
+	if a.Kind == KindProblem {
+		priority = m.config.Alerts.Pushover.Priority
+	}
+
+	// Prepare request body
+	data := url.Values{}
+	data.Set("token", appToken)
+	data.Set("user", userKey)
+	data.Set("message", a.Message)
+	data.Set("title", "Celestia Watchtower")
+	data.Set("priority", fmt.Sprintf("%d", priority))
+	if sound := m.config.Alerts.Pushover.Sound; sound != "" {
+		data.Set("sound", sound)
+	}
+
+	// Send request
+	resp, err := m.httpClient.PostForm("https://api.pushover.net/1/messages.json", data)
+	if err != nil {
+		return fmt.Errorf("failed to send Pushover alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusErrorf(resp.StatusCode, "Pushover API returned non-OK status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Teams card theme colors
+const (
+	teamsColorCritical = "E81123"
+	teamsColorWarning  = "FFB900"
+	teamsColorRecovery = "2EB886"
+	teamsColorTest     = "0078D7"
+)
+
+// sendTeamsAlert sends an alert as a MessageCard via a Teams incoming webhook
+func (m *Manager) sendTeamsAlert(a Alert) error {
+	webhook := m.config.Alerts.Teams.WebhookURL
+
+	if webhook == "" {
+		return fmt.Errorf("Teams webhook URL not configured")
+	}
+
+	// Pick title and color based on the alert kind, problems by severity
+	title, color := "Celestia Node Alert", teamsColorCritical
+	switch {
+	case a.Kind == KindRecovery:
+		title, color = "Celestia Node Recovered", teamsColorRecovery
+	case a.Kind == KindTest:
+		title, color = "Celestia Watchtower Test", teamsColorTest
+	case a.Kind == KindDigest:
+		title, color = "Celestia Watchtower Digest", teamsColorTest
+	case a.Kind == KindNotice:
+		title, color = "Celestia Node Notice", teamsColorTest
+	case a.Severity == SeverityWarning:
+		color = teamsColorWarning
+	case a.Severity == SeverityInfo:
+		color = teamsColorTest
+	}
+
+	facts := make([]map[string]string, 0, len(a.Facts))
+	for _, fact := range a.Facts {
+		facts = append(facts, map[string]string{"name": fact.Name, "value": fact.Value})
+	}
+
+	// Prepare request body
+	payload := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"summary":    title,
+		"themeColor": color,
+		"title":      title,
+		"sections": []map[string]interface{}{
+			{
+				"text":  strings.ReplaceAll(a.Message, "\n", "<br>"),
+				"facts": facts,
+			},
+		},
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Teams payload: %w", err)
+	}
+
+	// Send request
+	resp, err := m.httpClient.Post(webhook, "application/json", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to send Teams alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return statusErrorf(resp.StatusCode, "Teams API returned non-OK status: %s", resp.Status)
+	}
+
+	// Connectors answer a delivered card with a 200 and "1", and report
+	// some failures such as throttling with a 200 and an error text
+	if resp.StatusCode == http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		if text := strings.TrimSpace(string(body)); text != "" && text != "1" {
+			return fmt.Errorf("Teams webhook rejected the message: %s", text)
+		}
+	}
+
+	return nil
+}
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// sendPagerDutyAlert triggers or resolves one PagerDuty event per affected category
+func (m *Manager) sendPagerDutyAlert(a Alert) error {
+	routingKey := m.config.Alerts.PagerDuty.RoutingKey
+
+	if routingKey == "" {
+		return fmt.Errorf("PagerDuty routing key not configured")
+	}
+
+	details := make(map[string]string, len(a.Facts))
+	for _, fact := range a.Facts {
+		details[fact.Name] = fact.Value
+	}
+
+	switch a.Kind {
+	case KindTest:
+		// Resolve the test incident straight away so nobody stays paged
+		dedupKey := "celestia-watchtower/test"
+		if err := m.postPagerDutyEvent(routingKey, "trigger", dedupKey, a.Message, "info", details); err != nil {
+			return err
+		}
+		return m.postPagerDutyEvent(routingKey, "resolve", dedupKey, "", "", nil)
+	case KindRecovery:
+		for _, category := range a.Categories {
+			if err := m.postPagerDutyEvent(routingKey, "resolve", pagerDutyDedupKey(a.Node, category), "", "", nil); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, category := range a.Categories {
+			summary := fmt.Sprintf("Celestia node %s: %s issue", a.Node, category)
+			if err := m.postPagerDutyEvent(routingKey, "trigger", pagerDutyDedupKey(a.Node, category), summary, a.Severity.String(), details); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// pagerDutyDedupKey identifies the incident for a node and category
+func pagerDutyDedupKey(node, category string) string {
+	return fmt.Sprintf("celestia-watchtower/%s/%s", node, category)
+}
+
+// postPagerDutyEvent sends a single event to the PagerDuty Events API
+func (m *Manager) postPagerDutyEvent(routingKey, action, dedupKey, summary, severity string, details map[string]string) error {
+	// Prepare request body
+	payload := map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": action,
+		"dedup_key":    dedupKey,
+	}
+	if action == "trigger" {
+		payload["payload"] = map[string]interface{}{
+			"summary":        summary,
+			"source":         "celestia-watchtower",
+			"severity":       severity,
+			"custom_details": details,
+		}
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PagerDuty payload: %w", err)
+	}
+
+	// Send request
+	resp, err := m.httpClient.Post(pagerDutyEventsURL, "application/json", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to send PagerDuty %s event: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return statusErrorf(resp.StatusCode, "PagerDuty API returned non-Accepted status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// webexMessagesURL is the Webex API endpoint for posting messages
+const webexMessagesURL = "https://webexapis.com/v1/messages"
+
+// webexMaxRetryAfter caps how long the retry of a rate limited Webex
+// message waits, the delivery deadline still applies
+const webexMaxRetryAfter = 60 * time.Second
+
+// sendWebexAlert posts an alert to a Webex room as a bot
+func (m *Manager) sendWebexAlert(a Alert) error {
+	botToken := m.config.Alerts.Webex.BotToken
+	roomID := m.config.Alerts.Webex.RoomID
+
+	if botToken == "" || roomID == "" {
+		return fmt.Errorf("Webex bot token or room ID not configured")
+	}
+
+	// Prepare request body
+	payload := map[string]interface{}{
+		"roomId":   roomID,
+		"markdown": a.Message,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Webex payload: %w", err)
+	}
+
+	resp, err := m.postWebexMessage(botToken, jsonPayload)
+	if err != nil {
+		return err
+	}
+
+	// Webex rate limits with 429, the retry waits as told
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait := retryAfter(resp.Header.Get("Retry-After"), webexMaxRetryAfter)
+		return rateLimitErrorf(wait, "Webex API rate limited the message, retry after %s", wait)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return statusErrorf(resp.StatusCode, "Webex API returned non-OK status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// postWebexMessage sends a single message request to the Webex API
+func (m *Manager) postWebexMessage(botToken string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest("POST", webexMessagesURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Webex request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+botToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Webex alert: %w", err)
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// retryAfter parses a Retry-After header given in seconds, capped at max
+func retryAfter(header string, max time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return time.Second
+	}
+
+	return min(time.Duration(seconds)*time.Second, max)
+}
+
 // TestAlert sends a test alert to verify alert configuration
 func (m *Manager) TestAlert() error {
-	message := "🔔 This is a test alert from Celestia Watchtower.\n\nIf you're receiving this, your alert configuration is working correctly!"
-	return m.SendAlert(message)
+	return m.Send(m.testAlert())
+}
+
+// TestAlertTo sends a test alert to a single channel
+func (m *Manager) TestAlertTo(name string) error {
+	return m.SendAlertTo(name, m.testAlert())
+}
+
+// SetTestStatus sets the node status test alerts carry, so a webhook body
+// template using .Status renders in test-alert as in a real alert
+func (m *Manager) SetTestStatus(status interface{}) {
+	m.testStatus = status
+}
+
+// SetStatusDecoder sets how the status of an alert read back from the
+// outbox is decoded, so a webhook body template using .Status renders on
+// replay as it did when the alert was first sent. Without a decoder the
+// status comes back as a generic JSON map.
+func (m *Manager) SetStatusDecoder(decode func(data []byte) (interface{}, error)) {
+	m.decodeStatus = decode
+}
+
+// testAlert returns the alert sent by test-alert, naming the watched nodes
+func (m *Manager) testAlert() Alert {
+	var nodes []string
+	for _, node := range m.config.Node {
+		nodes = append(nodes, node.DisplayName())
+	}
+
+	message := "🔔 This is a test alert from Celestia Watchtower"
+	if len(nodes) > 0 {
+		message += " watching " + strings.Join(nodes, ", ")
+	}
+	message += ".\n\nIf you're receiving this, your alert configuration is working correctly!"
+	return Alert{Kind: KindTest, Message: message, Timestamp: time.Now(), Status: m.testStatus}
+}
+
+// SendAlertTo sends an alert to exactly one channel, ignoring its minimum
+// severity. Failed deliveries are not queued in the outbox.
+func (m *Manager) SendAlertTo(name string, a Alert) error {
+	ch, ok := m.channel(name)
+	if !ok {
+		var names []string
+		for _, ch := range m.channels() {
+			names = append(names, ch.name)
+		}
+		return fmt.Errorf("unknown alert channel %q, valid channels are: %s", name, strings.Join(names, ", "))
+	}
+	if !ch.enabled {
+		enabled := m.EnabledChannels()
+		if len(enabled) == 0 {
+			return fmt.Errorf("alert channel %q is not enabled and no channel is", name)
+		}
+		return fmt.Errorf("alert channel %q is not enabled, enabled channels are: %s", name, strings.Join(enabled, ", "))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err := m.deliverAll([]channel{ch}, a)[0]
+	delivery := Delivery{Channel: ch.name}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if historyErr := m.recordHistory(a, "", []Delivery{delivery}); historyErr != nil && err == nil {
+		return fmt.Errorf("History: %w", historyErr)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", ch.label, err)
+	}
+
+	return nil
 }
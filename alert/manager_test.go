@@ -0,0 +1,276 @@
+package alert
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// telegramStub is a Telegram Bot API that records the sendMessage forms it
+// receives and answers with the next queued response, OK once none are left
+type telegramStub struct {
+	mu        sync.Mutex
+	forms     []url.Values
+	responses []stubResponse
+}
+
+type stubResponse struct {
+	code        int
+	description string
+}
+
+func (s *telegramStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/sendMessage") {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forms = append(s.forms, r.PostForm)
+	if len(s.responses) > 0 {
+		resp := s.responses[0]
+		s.responses = s.responses[1:]
+		w.WriteHeader(resp.code)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"` + resp.description + `"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"ok":true}`))
+}
+
+// newTelegramManager returns a manager sending Telegram alerts to a stub
+// API in the given parse mode
+func newTelegramManager(t *testing.T, parseMode string, chatID string, responses ...stubResponse) (*Manager, *telegramStub) {
+	t.Helper()
+	stub := &telegramStub{responses: responses}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	cfg := testConfig(t)
+	cfg.Alerts.Telegram.Enabled = true
+	cfg.Alerts.Telegram.BotToken = "123:token"
+	cfg.Alerts.Telegram.ChatIDs = []string{chatID}
+	cfg.Alerts.Telegram.APIURL = server.URL
+	cfg.Alerts.Telegram.ParseMode = parseMode
+
+	m, err := NewManagerWithClient(cfg, &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewManagerWithClient: %v", err)
+	}
+	return m, stub
+}
+
+func TestTelegramSplitsEscapedMessage(t *testing.T) {
+	// Each line is 25 characters, 30 once MarkdownV2 escapes ( . ) - and !
+	const lines = 410
+	message := strings.Repeat("Peers: 12 (min. 5) - ok!\n", lines)
+
+	m, stub := newTelegramManager(t, "markdown", "-100123")
+	if err := m.sendTelegramAlert(Alert{Kind: KindProblem, Severity: SeverityCritical, Message: message}); err != nil {
+		t.Fatalf("sendTelegramAlert: %v", err)
+	}
+
+	// 136 escaped lines fit in 4096, split unescaped it would be 163 lines
+	// and 3 messages
+	if want := (lines + 135) / 136; len(stub.forms) != want {
+		t.Errorf("sent %d messages, want %d", len(stub.forms), want)
+	}
+
+	var joined strings.Builder
+	for i, form := range stub.forms {
+		text := form.Get("text")
+		if n := utf16Len(text); n > telegramMessageLimit {
+			t.Errorf("message %d is %d UTF-16 units, over the %d limit", i+1, n, telegramMessageLimit)
+		}
+		joined.WriteString(strings.NewReplacer(`\(`, "(", `\.`, ".", `\)`, ")", `\-`, "-", `\!`, "!").Replace(text))
+	}
+	if joined.String() != message {
+		t.Error("joined messages differ from the alert message")
+	}
+}
+
+func TestTelegramParseModes(t *testing.T) {
+	issue := Issue{Name: "Sync Issue", Detail: "Node is 12.5% behind (max: 10)", Context: []string{"Local Height: 1_000 <a>"}}
+	message := "⚠️ [node-1] Alert *test*\n" + issue.Text()
+
+	tests := []struct {
+		mode      string
+		text      string
+		parseMode string
+	}{
+		{
+			mode:      "markdown",
+			text:      "⚠️ \\[node\\-1\\] Alert \\*test\\*\n❌ *Sync Issue*: Node is 12\\.5% behind \\(max: 10\\)\n  • Local Height: 1\\_000 <a\\>\n\n",
+			parseMode: "MarkdownV2",
+		},
+		{
+			mode:      "html",
+			text:      "⚠️ [node-1] Alert *test*\n❌ <b>Sync Issue</b>: Node is 12.5% behind (max: 10)\n  • Local Height: 1_000 &lt;a&gt;\n\n",
+			parseMode: "HTML",
+		},
+		{mode: "plain", text: message},
+		{mode: "none", text: message},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			m, stub := newTelegramManager(t, tt.mode, "-100123")
+			err := m.sendTelegramAlert(Alert{Kind: KindProblem, Severity: SeverityCritical, Message: message, Issues: []Issue{issue}})
+			if err != nil {
+				t.Fatalf("sendTelegramAlert: %v", err)
+			}
+			if len(stub.forms) != 1 {
+				t.Fatalf("sent %d messages, want 1", len(stub.forms))
+			}
+
+			form := stub.forms[0]
+			if got := form.Get("chat_id"); got != "-100123" {
+				t.Errorf("chat_id = %q, want %q", got, "-100123")
+			}
+			if got := form.Get("text"); got != tt.text {
+				t.Errorf("text = %q, want %q", got, tt.text)
+			}
+			if got := form.Get("parse_mode"); got != tt.parseMode {
+				t.Errorf("parse_mode = %q, want %q", got, tt.parseMode)
+			}
+			if tt.parseMode == "" && form.Has("parse_mode") {
+				t.Error("plain text message sets parse_mode")
+			}
+		})
+	}
+}
+
+func TestTelegramPlainTextFallback(t *testing.T) {
+	const chatID = "@watch_tower-alerts.1"
+	message := "⚠️ [node_1] Alert (test) *done*\n"
+
+	m, stub := newTelegramManager(t, "markdown", chatID,
+		stubResponse{code: http.StatusBadRequest, description: "Bad Request: can't parse entities: Character '_' is reserved"})
+	if err := m.sendTelegramAlert(Alert{Kind: KindProblem, Severity: SeverityCritical, Message: message}); err != nil {
+		t.Fatalf("sendTelegramAlert: %v", err)
+	}
+	if len(stub.forms) != 2 {
+		t.Fatalf("sent %d requests, want the formatted one and a plain retry", len(stub.forms))
+	}
+
+	if got := stub.forms[0].Get("parse_mode"); got != "MarkdownV2" {
+		t.Errorf("first request parse_mode = %q, want MarkdownV2", got)
+	}
+	retry := stub.forms[1]
+	if retry.Has("parse_mode") {
+		t.Errorf("retry sets parse_mode %q, want none", retry.Get("parse_mode"))
+	}
+	if got := retry.Get("text"); got != message {
+		t.Errorf("retry text = %q, want the unescaped %q", got, message)
+	}
+	if got := retry.Get("chat_id"); got != chatID {
+		t.Errorf("retry chat_id = %q, want %q", got, chatID)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// Computed independently with Python's hmac module
+	tests := []struct {
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{"secret", 1700000000, `{"a":1}`, "sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"},
+		{"secret", 1700000000, "", "sha256=4bc5f74d868b97888288889c5d9d65df02526f94c1592a79fdf4fe8b26e311e5"},
+		{"s3cr3t", 1700000001, `{"a":1}`, "sha256=6b1ba38b696390c3f71d30bf27a0409a8c7a619c2a003287fe6cf0cf94a9aadf"},
+		{"", 0, "hello", "sha256=f86e245e1dc543e397654234baa443516964fc9299b1722750df1a69222ffdd7"},
+	}
+	for _, tt := range tests {
+		got := SignWebhook(tt.secret, time.Unix(tt.timestamp, 0), []byte(tt.body))
+		if got != tt.want {
+			t.Errorf("SignWebhook(%q, %d, %q) = %s, want %s", tt.secret, tt.timestamp, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestNoticesSkipIncidentChannels(t *testing.T) {
+	m, err := NewManagerWithClient(testConfig(t), http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewManagerWithClient: %v", err)
+	}
+
+	notice := Alert{Kind: KindNotice, Severity: SeverityInfo, Node: "node-1", Categories: []string{"restart"}}
+	for _, channel := range []string{"pagerduty", "opsgenie"} {
+		if m.routes(channel, "info", notice) {
+			t.Errorf("notice routed to %s, which would open an incident nothing resolves", channel)
+		}
+	}
+	for _, channel := range []string{"telegram", "slack", "webhook"} {
+		if !m.routes(channel, "info", notice) {
+			t.Errorf("notice not routed to %s", channel)
+		}
+	}
+}
+
+func TestTelegramThreadFallback(t *testing.T) {
+	tests := []struct {
+		description string
+		requests    int
+		wantErr     bool
+	}{
+		{"Bad Request: message thread not found", 2, false},
+		{"Bad Request: chat not found", 1, true},
+		{"Bad Request: message is too long", 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			m, stub := newTelegramManager(t, "plain", "-100123", stubResponse{code: http.StatusBadRequest, description: tt.description})
+			m.config.Alerts.Telegram.ThreadID = 7
+
+			err := m.sendTelegramAlert(Alert{Kind: KindProblem, Severity: SeverityCritical, Message: "test"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sendTelegramAlert error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(stub.forms) != tt.requests {
+				t.Fatalf("sent %d requests, want %d", len(stub.forms), tt.requests)
+			}
+			if got := stub.forms[0].Get("message_thread_id"); got != "7" {
+				t.Errorf("message_thread_id = %q, want 7", got)
+			}
+			if tt.requests == 2 && stub.forms[1].Has("message_thread_id") {
+				t.Error("retry in the main chat still sets message_thread_id")
+			}
+		})
+	}
+}
+
+func TestTestAlertRendersStatusTemplate(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := testConfig(t)
+	cfg.Alerts.Webhook.Enabled = true
+	cfg.Alerts.Webhook.URL = server.URL
+	cfg.Alerts.Webhook.BodyTemplate = `{"kind":"{{.Kind}}","height":{{.Status.LocalHeight}}}`
+
+	m, err := NewManagerWithClient(cfg, &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewManagerWithClient: %v", err)
+	}
+	m.SetTestStatus(struct{ LocalHeight uint64 }{LocalHeight: 42})
+
+	if err := m.TestAlertTo("webhook"); err != nil {
+		t.Fatalf("TestAlertTo: %v", err)
+	}
+	if want := `{"kind":"test","height":42}`; body != want {
+		t.Errorf("webhook body = %s, want %s", body, want)
+	}
+}
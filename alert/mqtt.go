@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// mqttClient keeps the MQTT library type out of the Manager definition
+type mqttClient = mqtt.Client
+
+// mqttTimeout bounds connecting to the broker and each publish
+const mqttTimeout = 10 * time.Second
+
+// sendMQTTAlert publishes the alert message to <prefix>/alert
+func (m *Manager) sendMQTTAlert(a Alert) error {
+	return m.publishMQTT("alert", []byte(a.Message))
+}
+
+// PublishStatus publishes the latest node statuses as JSON to <prefix>/status
+// when the MQTT channel is configured to do so
+func (m *Manager) PublishStatus(statuses interface{}) error {
+	if !m.config.Alerts.MQTT.Enabled || !m.config.Alerts.MQTT.PublishStatus {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	payload, err := json.Marshal(statuses)
+	if err != nil {
+		return fmt.Errorf("failed to marshal MQTT status: %w", err)
+	}
+
+	if err := m.publishMQTT("status", payload); err != nil {
+		return fmt.Errorf("MQTT: %w", err)
+	}
+
+	return nil
+}
+
+// publishMQTT publishes a payload to a topic below the configured prefix
+func (m *Manager) publishMQTT(topic string, payload []byte) error {
+	cfg := m.config.Alerts.MQTT
+
+	client, err := m.getMQTTClient()
+	if err != nil {
+		return err
+	}
+
+	token := client.Publish(cfg.TopicPrefix+"/"+topic, cfg.QoS, cfg.Retain, payload)
+	if !token.WaitTimeout(mqttTimeout) {
+		return fmt.Errorf("timed out publishing MQTT message after %s", mqttTimeout)
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("failed to publish MQTT message: %w", err)
+	}
+
+	return nil
+}
+
+// getMQTTClient connects to the broker on first use. The client reconnects
+// on its own after that, so it is kept for the lifetime of the Manager.
+func (m *Manager) getMQTTClient() (mqttClient, error) {
+	if m.mqttClient != nil {
+		return m.mqttClient, nil
+	}
+
+	cfg := m.config.Alerts.MQTT
+	if cfg.BrokerURL == "" {
+		return nil, fmt.Errorf("MQTT broker URL not configured")
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(cfg.BrokerURL).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetConnectTimeout(mqttTimeout).
+		SetAutoReconnect(true)
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(mqttTimeout) {
+		return nil, fmt.Errorf("timed out connecting to MQTT broker %s", cfg.BrokerURL)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s: %w", cfg.BrokerURL, err)
+	}
+
+	m.mqttClient = client
+	return client, nil
+}
@@ -0,0 +1,82 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsConn keeps the NATS library type out of the Manager definition
+type natsConn = *nats.Conn
+
+// natsTimeout bounds connecting to the server and confirming a publish
+const natsTimeout = 10 * time.Second
+
+// sendNATSAlert publishes the alert message to the configured subject and,
+// if enabled, the node status JSON to <subject>.status
+func (m *Manager) sendNATSAlert(a Alert) error {
+	cfg := m.config.Alerts.NATS
+
+	if cfg.Subject == "" {
+		return fmt.Errorf("NATS subject not configured")
+	}
+
+	conn, err := m.getNATSConn()
+	if err != nil {
+		return err
+	}
+
+	if err := conn.Publish(cfg.Subject, []byte(a.Message)); err != nil {
+		return fmt.Errorf("failed to publish NATS alert: %w", err)
+	}
+
+	if cfg.PublishStatus && a.Status != nil {
+		status, err := json.Marshal(a.Status)
+		if err != nil {
+			return fmt.Errorf("failed to marshal NATS status: %w", err)
+		}
+		if err := conn.Publish(cfg.Subject+".status", status); err != nil {
+			return fmt.Errorf("failed to publish NATS status: %w", err)
+		}
+	}
+
+	// Make sure the server received the messages
+	if err := conn.FlushTimeout(natsTimeout); err != nil {
+		return fmt.Errorf("NATS server %s did not confirm the alert: %w", conn.ConnectedUrlRedacted(), err)
+	}
+
+	return nil
+}
+
+// getNATSConn connects to the server on first use. The connection
+// reconnects on its own after that, so it is kept for the lifetime of the
+// Manager.
+func (m *Manager) getNATSConn() (natsConn, error) {
+	if m.natsConn != nil {
+		return m.natsConn, nil
+	}
+
+	cfg := m.config.Alerts.NATS
+	if cfg.ServerURL == "" {
+		return nil, fmt.Errorf("NATS server URL not configured")
+	}
+
+	opts := []nats.Option{
+		nats.Name("celestia-watchtower"),
+		nats.Timeout(natsTimeout),
+		nats.MaxReconnects(-1),
+	}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredentialsFile))
+	}
+
+	conn, err := nats.Connect(cfg.ServerURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS server %s: %w", cfg.ServerURL, err)
+	}
+
+	m.natsConn = conn
+	return conn, nil
+}
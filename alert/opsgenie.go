@@ -0,0 +1,131 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Opsgenie Alerts API endpoints per region
+const (
+	opsgenieAlertsURL   = "https://api.opsgenie.com/v2/alerts"
+	opsgenieEUAlertsURL = "https://api.eu.opsgenie.com/v2/alerts"
+)
+
+// opsgenieMessageLimit is the maximum length of an Opsgenie alert message
+const opsgenieMessageLimit = 130
+
+// sendOpsgenieAlert creates or closes one Opsgenie alert per affected category.
+// Alerts are identified by an alias, so repeated alerts of a category update
+// the open Opsgenie alert and a recovery closes it.
+func (m *Manager) sendOpsgenieAlert(a Alert) error {
+	apiKey := m.config.Alerts.Opsgenie.APIKey
+
+	if apiKey == "" {
+		return fmt.Errorf("Opsgenie API key not configured")
+	}
+
+	details := make(map[string]string, len(a.Facts))
+	for _, fact := range a.Facts {
+		details[fact.Name] = fact.Value
+	}
+
+	switch a.Kind {
+	case KindTest:
+		// Close the test alert straight away so nobody stays paged
+		alias := "celestia-watchtower/test"
+		if err := m.createOpsgenieAlert(apiKey, alias, "Celestia Watchtower test alert", a.Message, "P5", details); err != nil {
+			return err
+		}
+		return m.closeOpsgenieAlert(apiKey, alias)
+	case KindRecovery:
+		for _, category := range a.Categories {
+			if err := m.closeOpsgenieAlert(apiKey, pagerDutyDedupKey(a.Node, category)); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, category := range a.Categories {
+			message := fmt.Sprintf("Celestia node %s: %s issue", a.Node, category)
+			if err := m.createOpsgenieAlert(apiKey, pagerDutyDedupKey(a.Node, category), message, a.Message, opsgeniePriority(a.Severity), details); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// opsgeniePriority maps a severity to an Opsgenie priority
+func opsgeniePriority(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "P1"
+	case SeverityWarning:
+		return "P3"
+	default:
+		return "P5"
+	}
+}
+
+// opsgenieURL returns the Alerts API endpoint of the configured region
+func (m *Manager) opsgenieURL() string {
+	if m.config.Alerts.Opsgenie.Region == "eu" {
+		return opsgenieEUAlertsURL
+	}
+	return opsgenieAlertsURL
+}
+
+// createOpsgenieAlert creates an alert, which Opsgenie deduplicates by alias
+func (m *Manager) createOpsgenieAlert(apiKey, alias, message, description, priority string, details map[string]string) error {
+	if len(message) > opsgenieMessageLimit {
+		message = message[:opsgenieMessageLimit]
+	}
+
+	return m.postOpsgenie(apiKey, m.opsgenieURL(), "create", map[string]interface{}{
+		"message":     message,
+		"alias":       alias,
+		"description": description,
+		"priority":    priority,
+		"source":      "celestia-watchtower",
+		"details":     details,
+	})
+}
+
+// closeOpsgenieAlert closes the open alert with the given alias
+func (m *Manager) closeOpsgenieAlert(apiKey, alias string) error {
+	closeURL := fmt.Sprintf("%s/%s/close?identifierType=alias", m.opsgenieURL(), url.PathEscape(alias))
+	return m.postOpsgenie(apiKey, closeURL, "close", map[string]interface{}{
+		"source": "celestia-watchtower",
+		"note":   "Node recovered",
+	})
+}
+
+// postOpsgenie sends a request to the Opsgenie Alerts API
+func (m *Manager) postOpsgenie(apiKey, endpoint, action string, payload map[string]interface{}) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Opsgenie payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create Opsgenie request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+apiKey)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Opsgenie %s request: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return statusErrorf(resp.StatusCode, "Opsgenie API returned non-Accepted status: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package alert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// outboxEntry is an alert waiting to be delivered to a single channel. The
+// status of the alert is kept apart as raw JSON, to be decoded back into
+// its own type when the alert is delivered.
+type outboxEntry struct {
+	Channel   string          `json:"channel"`
+	QueuedAt  time.Time       `json:"queued_at"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	Alert     Alert           `json:"alert"`
+	Status    json.RawMessage `json:"status,omitempty"`
+}
+
+// OutboxResult summarizes a round of outbox deliveries
+type OutboxResult struct {
+	Delivered int // entries delivered this round
+	Dropped   int // entries dropped for being too old or for a disabled channel
+	Pending   int // entries still waiting
+}
+
+// enqueueOutbox queues an alert for each channel that failed to deliver it,
+// dropping the oldest entries once the outbox is full
+func (m *Manager) enqueueOutbox(a Alert, channels []string) error {
+	entries, err := m.loadOutbox()
+	if err != nil {
+		return err
+	}
+
+	var status json.RawMessage
+	if a.Status != nil {
+		if status, err = json.Marshal(a.Status); err != nil {
+			return fmt.Errorf("failed to marshal alert status: %w", err)
+		}
+		a.Status = nil
+	}
+
+	now := time.Now()
+	for _, channel := range channels {
+		entries = append(entries, outboxEntry{Channel: channel, QueuedAt: now, Attempts: 1, Alert: a, Status: status})
+	}
+
+	if maxEntries := m.config.Alerts.Outbox.MaxEntries; len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+
+	return m.saveOutbox(entries)
+}
+
+// DrainOutbox retries delivery of the queued alerts. A channel that fails
+// again is not retried for later entries until the next round, so a channel
+// that is still down does not delay the rest.
+func (m *Manager) DrainOutbox() (OutboxResult, error) {
+	var result OutboxResult
+
+	if !m.config.Alerts.Enabled || !m.config.Alerts.Outbox.Enabled {
+		return result, nil
+	}
+
+	// Queued alerts are held back like new ones during maintenance
+	if m.silencedBy(time.Now()) != "" {
+		return result, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries, err := m.loadOutbox()
+	if err != nil || len(entries) == 0 {
+		return result, err
+	}
+
+	maxAge := time.Duration(m.config.Alerts.Outbox.MaxAgeHours) * time.Hour
+	down := make(map[string]bool)
+	var pending []outboxEntry
+
+	for _, entry := range entries {
+		ch, ok := m.channel(entry.Channel)
+		if !ok || !ch.enabled || time.Since(entry.QueuedAt) > maxAge {
+			result.Dropped++
+			continue
+		}
+		if down[entry.Channel] {
+			pending = append(pending, entry)
+			continue
+		}
+
+		a := entry.Alert
+		if len(entry.Status) > 0 {
+			a.Status = m.queuedStatus(entry.Status)
+		}
+		a.Message = fmt.Sprintf("⏳ Delayed alert, first attempted at %s\n\n", m.config.FormatTime(entry.QueuedAt)) + a.Message
+		if err := ch.send(a); err != nil {
+			entry.Attempts++
+			entry.LastError = err.Error()
+			pending = append(pending, entry)
+			down[entry.Channel] = true
+			continue
+		}
+		result.Delivered++
+
+		// Record when the alert finally went out; a failure here does not undo the delivery
+		a.Timestamp = time.Now()
+		_ = m.recordHistory(a, "", []Delivery{{Channel: entry.Channel}})
+	}
+
+	result.Pending = len(pending)
+	if result.Delivered == 0 && result.Dropped == 0 && len(down) == 0 {
+		return result, nil
+	}
+
+	return result, m.saveOutbox(pending)
+}
+
+// queuedStatus decodes the status of a queued alert with the decoder set by
+// SetStatusDecoder, falling back to a generic JSON map
+func (m *Manager) queuedStatus(data json.RawMessage) interface{} {
+	if m.decodeStatus != nil {
+		if status, err := m.decodeStatus(data); err == nil {
+			return status
+		}
+	}
+	var status interface{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil
+	}
+	return status
+}
+
+// loadOutbox reads the queued entries, oldest first
+func (m *Manager) loadOutbox() ([]outboxEntry, error) {
+	outboxFile, err := m.config.OutboxFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(outboxFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read outbox file: %w", err)
+	}
+
+	var entries []outboxEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry outboxEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip lines torn by a crash rather than losing the whole queue
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read outbox file: %w", err)
+	}
+
+	return entries, nil
+}
+
+// saveOutbox replaces the outbox file with the given entries
+func (m *Manager) saveOutbox(entries []outboxEntry) error {
+	outboxFile, err := m.config.OutboxFilePath()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		if err := os.Remove(outboxFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove outbox file: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outboxFile), 0755); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal outbox entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial file
+	tmpFile := outboxFile + ".tmp"
+	if err := os.WriteFile(tmpFile, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, outboxFile); err != nil {
+		return fmt.Errorf("failed to replace outbox file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package alert
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// queuedStatus stands in for the monitor's node status, which the alert
+// package cannot import
+type queuedStatus struct {
+	LocalHeight uint64 `json:"local_height"`
+}
+
+func TestDrainOutboxRendersStatusTemplate(t *testing.T) {
+	var bodies []string
+	down := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := testConfig(t)
+	cfg.Alerts.MaxRetries = 0
+	cfg.Alerts.Outbox.Enabled = true
+	cfg.Alerts.Webhook.Enabled = true
+	cfg.Alerts.Webhook.URL = server.URL
+	cfg.Alerts.Webhook.BodyTemplate = `{"height":{{.Status.LocalHeight}}}`
+
+	m, err := NewManagerWithClient(cfg, &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewManagerWithClient: %v", err)
+	}
+	m.SetStatusDecoder(func(data []byte) (interface{}, error) {
+		status := &queuedStatus{}
+		if err := json.Unmarshal(data, status); err != nil {
+			return nil, err
+		}
+		return status, nil
+	})
+
+	a := Alert{Kind: KindProblem, Severity: SeverityCritical, Message: "test", Timestamp: time.Now(), Status: &queuedStatus{LocalHeight: 42}}
+	if err := m.Send(a); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("Send to a failing webhook returned %v, want the 503", err)
+	}
+
+	down = false
+	result, err := m.DrainOutbox()
+	if err != nil {
+		t.Fatalf("DrainOutbox: %v", err)
+	}
+	if result.Delivered != 1 || result.Pending != 0 {
+		t.Fatalf("DrainOutbox = %+v, want one delivery", result)
+	}
+	if want := `{"height":42}`; len(bodies) != 1 || bodies[0] != want {
+		t.Errorf("replayed webhook bodies = %q, want %s", bodies, want)
+	}
+}
@@ -0,0 +1,166 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// retryBaseDelay is the delay before the first retry of a failed delivery,
+// doubled for each further retry
+const retryBaseDelay = time.Second
+
+// statusError is returned when a channel's API answers with an unexpected
+// HTTP status
+type statusError struct {
+	code       int
+	msg        string
+	retryAfter time.Duration // wait before a retry asked for by a rate limited API
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// statusErrorf returns a statusError for the given HTTP status code
+func statusErrorf(code int, format string, args ...interface{}) error {
+	return &statusError{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+// rateLimitErrorf returns a statusError for a 429 answer whose retry is to
+// wait at least retryAfter
+func rateLimitErrorf(retryAfter time.Duration, format string, args ...interface{}) error {
+	return &statusError{code: http.StatusTooManyRequests, msg: fmt.Sprintf(format, args...), retryAfter: retryAfter}
+}
+
+// retryable reports whether a failed delivery may succeed when retried.
+// Server errors, rate limits that say when to retry and network errors are
+// retried; client errors such as a bad token or chat ID are not.
+func retryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code >= 500 || statusErr.retryAfter > 0
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// recipientError is a failed delivery to one recipient of a channel
+type recipientError struct {
+	recipient string
+	err       error
+}
+
+// recipientErrors is returned when every recipient of a channel failed.
+// It unwraps to the individual errors so the delivery is still retried
+// when they are retryable.
+type recipientErrors []recipientError
+
+func (e recipientErrors) Error() string {
+	return formatRecipientErrors(e)
+}
+
+func (e recipientErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, re := range e {
+		errs[i] = re.err
+	}
+	return errs
+}
+
+// formatRecipientErrors lists each failed recipient with its error
+func formatRecipientErrors(errs []recipientError) string {
+	parts := make([]string, len(errs))
+	for i, re := range errs {
+		parts[i] = fmt.Sprintf("%s: %v", re.recipient, re.err)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// joinRecipientErrors aggregates the failures of a channel with the given
+// number of recipients. A single recipient's error is returned unchanged.
+// When only some recipients failed the error is not retryable, so a retry
+// doesn't resend the alert to recipients that already received it.
+func joinRecipientErrors(recipients int, errs []recipientError) error {
+	switch {
+	case len(errs) == 0:
+		return nil
+	case recipients == 1:
+		return errs[0].err
+	case len(errs) == recipients:
+		return recipientErrors(errs)
+	default:
+		return errors.New(formatRecipientErrors(errs))
+	}
+}
+
+// deliver sends an alert to a channel, retrying retryable failures up to
+// alerts.max_retries times with exponential backoff. No retry is started
+// once the context is done, but an attempt in flight is not interrupted:
+// the senders do not take the context, so a hung attempt is only bounded
+// by alerts.http_timeout_seconds or the channel's own client timeout.
+func (m *Manager) deliver(ctx context.Context, ch channel, a Alert) error {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		err := ch.send(a)
+		if err == nil {
+			return nil
+		}
+		if attempt >= m.config.Alerts.MaxRetries || !retryable(err) {
+			if attempt > 0 {
+				return fmt.Errorf("failed after %d attempts: %w", attempt+1, err)
+			}
+			return err
+		}
+
+		// A rate limited API may ask for a longer wait than the backoff
+		wait := delay
+		var statusErr *statusError
+		if errors.As(err, &statusErr) {
+			wait = max(wait, statusErr.retryAfter)
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("gave up after %d attempts, delivery deadline exceeded: %w", attempt+1, err)
+		}
+		delay *= 2
+	}
+}
+
+// deliverAll sends an alert to the given channels concurrently, so a slow
+// channel does not hold back the others, and returns each channel's error
+// in the order of the channels. Retries stop at alerts.send_timeout_seconds,
+// an attempt already started still runs to its own timeout.
+func (m *Manager) deliverAll(channels []channel, a Alert) []error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.deliveryTimeout())
+	defer cancel()
+
+	errs := make([]error, len(channels))
+	var wg sync.WaitGroup
+	for i, ch := range channels {
+		wg.Add(1)
+		go func(i int, ch channel) {
+			defer wg.Done()
+			errs[i] = m.deliver(ctx, ch, a)
+		}(i, ch)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// deliveryTimeout returns the time deliveries may spend retrying
+func (m *Manager) deliveryTimeout() time.Duration {
+	if seconds := m.config.Alerts.SendTimeoutSeconds; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Minute
+}
@@ -0,0 +1,134 @@
+package alert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// testConfig returns a config with alerts enabled and every channel off,
+// keeping the watchtower's files in a temporary home directory
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.Alerts.Enabled = true
+	cfg.Alerts.History.Enabled = false
+	cfg.Alerts.Outbox.Enabled = false
+	return cfg
+}
+
+// delayedServer answers every request with 200 OK after delay
+func delayedServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSendDeliversConcurrently(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	cfg := testConfig(t)
+	cfg.Alerts.Slack.Enabled = true
+	cfg.Alerts.Slack.WebhookURL = delayedServer(t, delay).URL
+	cfg.Alerts.Teams.Enabled = true
+	cfg.Alerts.Teams.WebhookURL = delayedServer(t, delay).URL
+	cfg.Alerts.Webhook.Enabled = true
+	cfg.Alerts.Webhook.URL = delayedServer(t, delay).URL
+
+	m, err := NewManagerWithClient(cfg, &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewManagerWithClient: %v", err)
+	}
+
+	start := time.Now()
+	err = m.Send(Alert{Kind: KindProblem, Severity: SeverityCritical, Message: "test", Timestamp: time.Now()})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	// In sequence the three channels would take 600ms
+	if elapsed < delay || elapsed > 2*delay {
+		t.Errorf("Send took %v, want about the slowest channel's %v", elapsed, delay)
+	}
+}
+
+// redirectTransport sends every request to the server at target, for
+// channels whose API URL cannot be configured
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWebexRetryAfter(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryAfter   string
+		sendTimeout  int
+		wantRequests int32
+		wantErr      string
+		minElapsed   time.Duration
+		maxElapsed   time.Duration
+	}{
+		{"retried after the wait", "2", 30, 2, "", 2 * time.Second, 4 * time.Second},
+		{"wait past the delivery deadline", "30", 1, 1, "deadline exceeded", 0, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}))
+			t.Cleanup(server.Close)
+			target, _ := url.Parse(server.URL)
+
+			cfg := testConfig(t)
+			cfg.Alerts.SendTimeoutSeconds = tt.sendTimeout
+			cfg.Alerts.Webex.Enabled = true
+			cfg.Alerts.Webex.BotToken = "token"
+			cfg.Alerts.Webex.RoomID = "room"
+
+			m, err := NewManagerWithClient(cfg, &http.Client{Timeout: 5 * time.Second, Transport: redirectTransport{target}})
+			if err != nil {
+				t.Fatalf("NewManagerWithClient: %v", err)
+			}
+
+			start := time.Now()
+			err = m.Send(Alert{Kind: KindProblem, Severity: SeverityCritical, Message: "test", Timestamp: time.Now()})
+			elapsed := time.Since(start)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Send returned %v, want an error containing %q", err, tt.wantErr)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("Webex got %d requests, want %d", got, tt.wantRequests)
+			}
+			if elapsed < tt.minElapsed || elapsed > tt.maxElapsed {
+				t.Errorf("Send took %v, want between %v and %v", elapsed, tt.minElapsed, tt.maxElapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package alert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how serious an alert is
+type Severity int
+
+// Alert severities, in increasing order
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityWarning
+	SeverityCritical
+)
+
+// String returns the config name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// ParseSeverity parses a severity name from the config. An empty name means info,
+// so a channel without a threshold receives every alert.
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "info":
+		return SeverityInfo, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "critical":
+		return SeverityCritical, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q, expected \"info\", \"warning\" or \"critical\"", name)
+	}
+}
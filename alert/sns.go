@@ -0,0 +1,138 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/smithy-go"
+)
+
+// snsClient keeps the SDK type out of the Manager definition
+type snsClient = *sns.Client
+
+// snsTimeout bounds a single SNS publish, including credential resolution
+const snsTimeout = 15 * time.Second
+
+// snsSMSLimit is the maximum size of an SMS sent through SNS in bytes
+const snsSMSLimit = 1600
+
+// snsTruncatedMarker ends an SMS that was cut to fit snsSMSLimit
+const snsTruncatedMarker = "... [truncated]"
+
+// sendSNSAlert publishes an alert to an AWS SNS topic, or sends it as an
+// SMS to a phone number. SMS get the compact single line form.
+func (m *Manager) sendSNSAlert(a Alert) error {
+	cfg := m.config.Alerts.SNS
+
+	if cfg.Region == "" || (cfg.TopicARN == "" && cfg.PhoneNumber == "") {
+		return fmt.Errorf("SNS region and topic ARN or phone number not configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snsTimeout)
+	defer cancel()
+
+	client, err := m.getSNSClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	sms := truncateBytes(shortMessage(a), snsSMSLimit, snsTruncatedMarker)
+
+	var input *sns.PublishInput
+	destination := cfg.TopicARN
+	if cfg.TopicARN == "" {
+		destination = cfg.PhoneNumber
+		input = &sns.PublishInput{
+			PhoneNumber: aws.String(cfg.PhoneNumber),
+			Message:     aws.String(sms),
+		}
+	} else {
+		subject := "Celestia Node Alert"
+		switch a.Kind {
+		case KindRecovery:
+			subject = "Celestia Node Recovered"
+		case KindTest:
+			subject = "Celestia Watchtower Test"
+		case KindDigest:
+			subject = "Celestia Watchtower Digest"
+		case KindNotice:
+			subject = "Celestia Node Notice"
+		}
+
+		// SMS subscribers of the topic get the compact form, others the full message
+		message, err := json.Marshal(map[string]string{"default": a.Message, "sms": sms})
+		if err != nil {
+			return fmt.Errorf("failed to marshal SNS message: %w", err)
+		}
+		input = &sns.PublishInput{
+			TopicArn:         aws.String(cfg.TopicARN),
+			Subject:          aws.String(subject),
+			Message:          aws.String(string(message)),
+			MessageStructure: aws.String("json"),
+		}
+	}
+
+	_, err = client.Publish(ctx, input)
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.ErrorCode() {
+			case "AuthorizationError", "AccessDenied", "AccessDeniedException":
+				return fmt.Errorf("access denied publishing to %s, check that the credentials allow sns:Publish: %s", destination, apiErr.ErrorMessage())
+			}
+		}
+		return fmt.Errorf("failed to publish SNS alert: %w", err)
+	}
+
+	return nil
+}
+
+// truncateBytes shortens s to at most limit bytes, ending it with marker
+// when it was cut. Multi-byte characters are not split.
+func truncateBytes(s string, limit int, marker string) string {
+	if len(s) <= limit {
+		return s
+	}
+
+	cut := limit - len(marker)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + marker
+}
+
+// getSNSClient creates the SNS client on first use so the AWS SDK is only
+// initialized when the channel is enabled
+func (m *Manager) getSNSClient(ctx context.Context) (*sns.Client, error) {
+	if m.snsClient != nil {
+		return m.snsClient, nil
+	}
+
+	cfg := m.config.Alerts.SNS
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.Region),
+	}
+
+	// Static credentials take precedence over the default credential chain
+	if cfg.AccessKeyID != "" || cfg.SecretAccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	m.snsClient = sns.NewFromConfig(awsCfg)
+	return m.snsClient, nil
+}
@@ -0,0 +1,281 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Long polling of the Telegram Bot API for button taps
+const (
+	telegramPollTimeout    = 30 * time.Second // how long getUpdates waits for an update
+	telegramPollRetryDelay = 10 * time.Second // pause after a failed poll
+)
+
+// telegramAckPrefix starts the callback data of the Acknowledge button,
+// followed by the node name
+const telegramAckPrefix = "ack:"
+
+// telegramCallbackDataLimit is the maximum length of a button's callback data
+const telegramCallbackDataLimit = 64
+
+// TelegramAck acknowledges alerts from Telegram, with the Acknowledge
+// button of an alert or the /ack command
+type TelegramAck struct {
+	Node string        // node the alert was about, empty for every node with an open alert
+	By   string        // Telegram user who acknowledged
+	For  time.Duration // how long repeated alerts are held back, 0 until the node recovers
+}
+
+// TelegramHandler answers the acknowledgements and commands sent to the bot
+type TelegramHandler interface {
+	// Acknowledge holds back repeated alerts and returns the reply
+	Acknowledge(ack TelegramAck) string
+	// Status returns the latest status of every node
+	Status() string
+}
+
+// telegramHelp is the reply to /help and /start
+const telegramHelp = `Commands:
+/status - latest status of every node
+/ack [minutes] [node] - hold back repeated alerts of the open problems, until recovery or for the given minutes`
+
+// telegramAckUsage is the reply to /ack with arguments it does not understand
+const telegramAckUsage = "Usage: /ack [minutes] [node], with minutes greater than 0"
+
+// telegramUser is the sender of a Telegram update
+type telegramUser struct {
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+}
+
+// name returns the user's @username, or their first name without one
+func (u telegramUser) name() string {
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	return u.FirstName
+}
+
+// telegramChat is the chat a Telegram message was posted in
+type telegramChat struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// telegramMessage is a message posted in a chat the bot is in
+type telegramMessage struct {
+	Text string       `json:"text"`
+	From telegramUser `json:"from"`
+	Chat telegramChat `json:"chat"`
+}
+
+// telegramUpdate is an update returned by getUpdates. Only button taps
+// and messages are requested.
+type telegramUpdate struct {
+	UpdateID      int              `json:"update_id"`
+	Message       *telegramMessage `json:"message"`
+	CallbackQuery *struct {
+		ID      string           `json:"id"`
+		From    telegramUser     `json:"from"`
+		Data    string           `json:"data"`
+		Message *telegramMessage `json:"message"`
+	} `json:"callback_query"`
+}
+
+// telegramKeyboard returns the inline keyboard with the Acknowledge button
+// for an alert, or an empty string if the alert gets none
+func (m *Manager) telegramKeyboard(a Alert) string {
+	data := telegramAckPrefix + a.Node
+	if !m.config.Alerts.Telegram.Interactive || a.Kind != KindProblem || a.Node == "" || len(data) > telegramCallbackDataLimit {
+		return ""
+	}
+
+	keyboard, err := json.Marshal(map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{{{"text": "✅ Acknowledge", "callback_data": data}}},
+	})
+	if err != nil {
+		return ""
+	}
+	return string(keyboard)
+}
+
+// PollTelegram long-polls the Telegram bot for taps on the Acknowledge
+// button and for the /ack and /status commands until ctx is done. handler
+// answers those from a configured chat, its reply is posted to the chat.
+// Failed polls are reported to onError and retried after a pause.
+func (m *Manager) PollTelegram(ctx context.Context, handler TelegramHandler, onError func(error)) {
+	offset := 0
+	for ctx.Err() == nil {
+		updates, err := m.telegramUpdates(ctx, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			onError(err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(telegramPollRetryDelay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			if err := m.handleTelegramUpdate(update, handler); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// telegramUpdates waits for the button taps and messages after offset
+func (m *Manager) telegramUpdates(ctx context.Context, offset int) ([]telegramUpdate, error) {
+	cfg := m.config.Alerts.Telegram
+
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("timeout", strconv.Itoa(int(telegramPollTimeout.Seconds())))
+	query.Set("allowed_updates", `["callback_query","message"]`)
+	apiURL := fmt.Sprintf("%s/bot%s/getUpdates?%s", baseURL(cfg.APIURL, telegramAPIURL), cfg.BotToken, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Telegram request: %w", err)
+	}
+
+	// The request is held open for the poll timeout, longer than alerts may take
+	client := &http.Client{Transport: m.httpClient.Transport, Timeout: telegramPollTimeout + m.httpClient.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll Telegram updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Result      []telegramUpdate `json:"result"`
+		Description string           `json:"description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("failed to parse Telegram updates: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// A bot with a webhook set cannot be polled
+		return nil, statusErrorf(resp.StatusCode, "Telegram API returned non-OK status: %s: %s", resp.Status, body.Description)
+	}
+
+	return body.Result, nil
+}
+
+// handleTelegramUpdate answers a button tap or command and replies in the
+// chat it came from. Taps and commands from other chats are refused.
+func (m *Manager) handleTelegramUpdate(update telegramUpdate, handler TelegramHandler) error {
+	if update.Message != nil {
+		return m.handleTelegramCommand(update.Message, handler)
+	}
+
+	query := update.CallbackQuery
+	if query == nil || query.Message == nil {
+		return nil
+	}
+	node, ok := strings.CutPrefix(query.Data, telegramAckPrefix)
+	if !ok {
+		return nil
+	}
+
+	chat := query.Message.Chat
+	if !m.telegramChatAllowed(chat) {
+		return m.answerTelegramCallback(query.ID, "This chat cannot acknowledge alerts")
+	}
+
+	reply := handler.Acknowledge(TelegramAck{Node: node, By: query.From.name()})
+	if err := m.answerTelegramCallback(query.ID, reply); err != nil {
+		return err
+	}
+	return m.sendTelegramMessage(m.config.Alerts.Telegram.BotToken, strconv.FormatInt(chat.ID, 10), reply, nil, "")
+}
+
+// handleTelegramCommand answers the /ack, /status and /help commands.
+// Other messages are ignored.
+func (m *Manager) handleTelegramCommand(message *telegramMessage, handler TelegramHandler) error {
+	fields := strings.Fields(message.Text)
+	if len(fields) == 0 || !m.telegramChatAllowed(message.Chat) {
+		return nil
+	}
+
+	// Commands in groups may be addressed to the bot, as in /ack@some_bot
+	command, _, _ := strings.Cut(strings.ToLower(fields[0]), "@")
+
+	var reply string
+	switch command {
+	case "/status":
+		reply = handler.Status()
+	case "/ack":
+		ack, ok := parseTelegramAck(fields[1:])
+		if !ok {
+			reply = telegramAckUsage
+			break
+		}
+		ack.By = message.From.name()
+		reply = handler.Acknowledge(ack)
+	case "/help", "/start":
+		reply = telegramHelp
+	default:
+		return nil
+	}
+
+	return m.sendTelegramMessage(m.config.Alerts.Telegram.BotToken, strconv.FormatInt(message.Chat.ID, 10), reply, nil, "")
+}
+
+// parseTelegramAck parses the arguments of /ack: an optional number of
+// minutes and an optional node name, in any order. ok is false for
+// anything else.
+func parseTelegramAck(args []string) (ack TelegramAck, ok bool) {
+	for _, arg := range args {
+		if minutes, err := strconv.Atoi(arg); err == nil {
+			if minutes <= 0 || ack.For > 0 {
+				return ack, false
+			}
+			ack.For = time.Duration(minutes) * time.Minute
+			continue
+		}
+		if ack.Node != "" {
+			return ack, false
+		}
+		ack.Node = arg
+	}
+	return ack, true
+}
+
+// telegramChatAllowed reports whether the chat is one of the configured chats
+func (m *Manager) telegramChatAllowed(chat telegramChat) bool {
+	chatIDs := m.config.Alerts.Telegram.ChatIDs
+	return slices.Contains(chatIDs, strconv.FormatInt(chat.ID, 10)) ||
+		(chat.Username != "" && slices.Contains(chatIDs, "@"+chat.Username))
+}
+
+// answerTelegramCallback shows a short notice to the user who tapped a button
+func (m *Manager) answerTelegramCallback(queryID, text string) error {
+	cfg := m.config.Alerts.Telegram
+	apiURL := fmt.Sprintf("%s/bot%s/answerCallbackQuery", baseURL(cfg.APIURL, telegramAPIURL), cfg.BotToken)
+
+	data := url.Values{}
+	data.Set("callback_query_id", queryID)
+	data.Set("text", text)
+
+	resp, description, err := m.postTelegram(apiURL, data)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return statusErrorf(resp.StatusCode, "Telegram API returned non-OK status: %s: %s", resp.Status, description)
+	}
+	return nil
+}
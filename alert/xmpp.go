@@ -0,0 +1,266 @@
+package alert
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// xmppTimeout bounds a whole XMPP session, from dialing to closing the stream
+const xmppTimeout = 30 * time.Second
+
+// XMPP namespaces used by the client
+const (
+	xmppNSClient = "jabber:client"
+	xmppNSStream = "http://etherx.jabber.org/streams"
+	xmppNSTLS    = "urn:ietf:params:xml:ns:xmpp-tls"
+	xmppNSSASL   = "urn:ietf:params:xml:ns:xmpp-sasl"
+	xmppNSBind   = "urn:ietf:params:xml:ns:xmpp-bind"
+)
+
+// xmppFeatures is the subset of stream features the client understands
+type xmppFeatures struct {
+	StartTLS   *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-tls starttls"`
+	Mechanisms []string  `xml:"urn:ietf:params:xml:ns:xmpp-sasl mechanisms>mechanism"`
+	Bind       *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-bind bind"`
+}
+
+// xmppSession is a single client connection to an XMPP server
+type xmppSession struct {
+	conn    net.Conn
+	decoder *xml.Decoder
+	domain  string
+}
+
+// sendXMPPAlert sends an alert as a chat message over XMPP. A session is
+// opened on demand for each alert, so no connection is kept between checks.
+func (m *Manager) sendXMPPAlert(a Alert) error {
+	cfg := m.config.Alerts.XMPP
+
+	if cfg.JID == "" || cfg.Password == "" || cfg.Recipient == "" {
+		return fmt.Errorf("XMPP JID, password or recipient not configured")
+	}
+
+	user, domain, ok := strings.Cut(cfg.JID, "@")
+	if !ok || user == "" || domain == "" {
+		return fmt.Errorf("invalid XMPP JID %q", cfg.JID)
+	}
+	domain, _, _ = strings.Cut(domain, "/")
+
+	server := cfg.Server
+	if server == "" {
+		server = net.JoinHostPort(domain, "5222")
+	}
+
+	// Connect, secure and authenticate the stream
+	conn, err := net.DialTimeout("tcp", server, xmppTimeout)
+	if err != nil {
+		return fmt.Errorf("XMPP connection error: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(xmppTimeout))
+
+	s := &xmppSession{conn: conn, domain: domain}
+	if err := s.startTLS(server); err != nil {
+		return fmt.Errorf("XMPP TLS error: %w", err)
+	}
+	if err := s.authenticate(user, cfg.Password); err != nil {
+		return fmt.Errorf("XMPP authentication error: %w", err)
+	}
+
+	// Deliver the message
+	if err := s.sendMessage(cfg.Recipient, a.Message); err != nil {
+		return fmt.Errorf("XMPP delivery error: %w", err)
+	}
+
+	return nil
+}
+
+// openStream starts a new XML stream and returns the server's features
+func (s *xmppSession) openStream() (*xmppFeatures, error) {
+	s.decoder = xml.NewDecoder(s.conn)
+	if _, err := fmt.Fprintf(s.conn, "<?xml version='1.0'?><stream:stream to='%s' xmlns='%s' xmlns:stream='%s' version='1.0'>",
+		xmlEscape(s.domain), xmppNSClient, xmppNSStream); err != nil {
+		return nil, err
+	}
+
+	start, err := s.nextElement()
+	if err != nil {
+		return nil, err
+	}
+	if start.Name.Space != xmppNSStream || start.Name.Local != "stream" {
+		return nil, fmt.Errorf("unexpected element <%s> instead of stream", start.Name.Local)
+	}
+
+	start, err = s.nextElement()
+	if err != nil {
+		return nil, err
+	}
+	if start.Name.Space != xmppNSStream || start.Name.Local != "features" {
+		return nil, fmt.Errorf("unexpected element <%s> instead of stream features", start.Name.Local)
+	}
+
+	features := &xmppFeatures{}
+	if err := s.decoder.DecodeElement(features, &start); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// startTLS upgrades the connection with STARTTLS. Servers that do not offer
+// it are refused so the password is never sent in the clear.
+func (s *xmppSession) startTLS(server string) error {
+	features, err := s.openStream()
+	if err != nil {
+		return err
+	}
+	if features.StartTLS == nil {
+		return fmt.Errorf("server does not offer STARTTLS")
+	}
+
+	if _, err := fmt.Fprintf(s.conn, "<starttls xmlns='%s'/>", xmppNSTLS); err != nil {
+		return err
+	}
+	reply, err := s.nextElement()
+	if err != nil {
+		return err
+	}
+	if reply.Name.Local != "proceed" {
+		return fmt.Errorf("server refused STARTTLS")
+	}
+
+	host, _, err := net.SplitHostPort(server)
+	if err != nil {
+		host = s.domain
+	}
+	tlsConn := tls.Client(s.conn, &tls.Config{ServerName: host})
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+	s.conn = tlsConn
+
+	return nil
+}
+
+// authenticate logs in with SASL PLAIN and binds a resource
+func (s *xmppSession) authenticate(user, password string) error {
+	features, err := s.openStream()
+	if err != nil {
+		return err
+	}
+
+	plain := false
+	for _, mechanism := range features.Mechanisms {
+		plain = plain || mechanism == "PLAIN"
+	}
+	if !plain {
+		return fmt.Errorf("server does not support PLAIN authentication")
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + user + "\x00" + password))
+	if _, err := fmt.Fprintf(s.conn, "<auth xmlns='%s' mechanism='PLAIN'>%s</auth>", xmppNSSASL, credentials); err != nil {
+		return err
+	}
+	reply, err := s.nextElement()
+	if err != nil {
+		return err
+	}
+	if reply.Name.Local != "success" {
+		var failure struct {
+			Reason struct {
+				XMLName xml.Name
+			} `xml:",any"`
+		}
+		s.decoder.DecodeElement(&failure, &reply)
+		return fmt.Errorf("server rejected credentials: %s", failure.Reason.XMLName.Local)
+	}
+
+	// The stream restarts after authentication
+	if _, err := s.openStream(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(s.conn, "<iq type='set' id='bind'><bind xmlns='%s'><resource>celestia-watchtower</resource></bind></iq>", xmppNSBind); err != nil {
+		return err
+	}
+	reply, err = s.nextElement()
+	if err != nil {
+		return err
+	}
+	if err := s.decoder.Skip(); err != nil {
+		return err
+	}
+	for _, attr := range reply.Attr {
+		if attr.Name.Local == "type" && attr.Value != "result" {
+			return fmt.Errorf("resource binding failed")
+		}
+	}
+
+	return nil
+}
+
+// sendMessage sends a chat message and closes the stream, reporting any
+// error the server returns before the stream ends
+func (s *xmppSession) sendMessage(to, body string) error {
+	if _, err := fmt.Fprintf(s.conn, "<message to='%s' type='chat'><body>%s</body></message></stream:stream>",
+		xmlEscape(to), xmlEscape(body)); err != nil {
+		return err
+	}
+
+	for {
+		start, err := s.nextElement()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// The server closing its side of the stream ends the session
+			if _, ok := err.(*xml.SyntaxError); ok {
+				return nil
+			}
+			return err
+		}
+
+		isError := start.Name.Local == "error"
+		for _, attr := range start.Attr {
+			isError = isError || (attr.Name.Local == "type" && attr.Value == "error")
+		}
+		if isError {
+			return fmt.Errorf("server returned an error for <%s>", start.Name.Local)
+		}
+		if err := s.decoder.Skip(); err != nil {
+			return nil
+		}
+	}
+}
+
+// nextElement returns the next start element of the stream. The end of the
+// stream is reported as io.EOF.
+func (s *xmppSession) nextElement() (xml.StartElement, error) {
+	for {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			return t, nil
+		case xml.EndElement:
+			if t.Name.Space == xmppNSStream && t.Name.Local == "stream" {
+				return xml.StartElement{}, io.EOF
+			}
+		}
+	}
+}
+
+// xmlEscape escapes text for use in XML character data and attributes
+func xmlEscape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
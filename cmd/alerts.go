@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/21state/celestia-watchtower/alert"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alertsJSON  bool
+	alertsLimit int
+	alertsNode  string
+	alertsSince time.Duration
+)
+
+// alertsMessageWidth is the width of the message column in the table
+const alertsMessageWidth = 60
+
+// alertsCmd represents the alerts command
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Show recently sent alerts",
+	Long:  `Show the most recent alerts recorded by the watchtower, with the delivery result of each channel.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runAlerts()
+	},
+}
+
+func init() {
+	alertsCmd.Flags().BoolVar(&alertsJSON, "json", false, "Print the alerts as JSON")
+	alertsCmd.Flags().IntVarP(&alertsLimit, "limit", "n", 20, "Number of alerts to show (0 shows all)")
+	alertsCmd.Flags().StringVar(&alertsNode, "node", "", "Only show alerts about this node")
+	alertsCmd.Flags().DurationVar(&alertsSince, "since", 0, "Only show alerts from this long ago, e.g. 24h")
+	rootCmd.AddCommand(alertsCmd)
+}
+
+// runAlerts prints the last alerts from the alert history file
+func runAlerts() {
+	history, err := alert.LoadAlertHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading alert history: %v\n", err)
+		os.Exit(1)
+	}
+
+	var filtered []alert.HistoryEntry
+	for _, entry := range history {
+		if alertsNode != "" && entry.Node != alertsNode {
+			continue
+		}
+		if alertsSince > 0 && time.Since(entry.Timestamp) > alertsSince {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	history = filtered
+
+	if alertsLimit > 0 && len(history) > alertsLimit {
+		history = history[len(history)-alertsLimit:]
+	}
+
+	if alertsJSON {
+		data, err := json.MarshalIndent(history, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding alert history: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(history) == 0 {
+		fmt.Println("No alerts recorded.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tNODE\tKIND\tSEVERITY\tRESULT\tCHANNELS\tMESSAGE")
+	for _, entry := range history {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp.Format("2006-01-02 15:04:05"),
+			entry.Node,
+			entry.Kind,
+			entry.Severity,
+			alertResult(entry),
+			alertChannels(entry),
+			alertSummary(entry))
+	}
+	w.Flush()
+
+	// Failed deliveries are listed in full below the table
+	for _, entry := range history {
+		for _, d := range entry.Deliveries {
+			if d.Error != "" {
+				fmt.Printf("❌ %s %s: %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"), d.Channel, d.Error)
+			}
+		}
+	}
+}
+
+// alertResult summarizes the delivery of a recorded alert
+func alertResult(entry alert.HistoryEntry) string {
+	if entry.Silenced != "" {
+		return "SILENCED"
+	}
+	if len(entry.Deliveries) == 0 {
+		return "NO CHANNEL"
+	}
+
+	failed := 0
+	for _, d := range entry.Deliveries {
+		if d.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Sprintf("FAILED %d/%d", failed, len(entry.Deliveries))
+	}
+	return "OK"
+}
+
+// alertChannels lists the channels of a recorded alert, marking failures
+func alertChannels(entry alert.HistoryEntry) string {
+	var channels []string
+	for _, d := range entry.Deliveries {
+		if d.Error != "" {
+			channels = append(channels, d.Channel+" ✗")
+		} else {
+			channels = append(channels, d.Channel)
+		}
+	}
+	return strings.Join(channels, ", ")
+}
+
+// alertSummary returns the first line of the message, shortened to fit the table
+func alertSummary(entry alert.HistoryEntry) string {
+	summary, _, _ := strings.Cut(strings.TrimSpace(entry.Message), "\n")
+	if runes := []rune(summary); len(runes) > alertsMessageWidth {
+		summary = string(runes[:alertsMessageWidth-1]) + "…"
+	}
+	return summary
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var dashboardLive bool
+
+// dashboardPoints is the number of checks kept per node for the sparklines
+const dashboardPoints = 240
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// dashboardCmd represents the dashboard command
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Show a live terminal dashboard",
+	Long: `Show a terminal dashboard of each node's sync height, peers, NAT status and the recent height difference
+and bandwidth, refreshed every check interval. It reads the history recorded by a running
+'celestia-watchtower start' process, or checks the nodes directly with --live. Press q to quit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runDashboard()
+	},
+}
+
+func init() {
+	dashboardCmd.Flags().BoolVar(&dashboardLive, "live", false, "Check the nodes directly instead of reading the history file")
+	rootCmd.AddCommand(dashboardCmd)
+}
+
+// dashboardSource returns the recent checks of each node, oldest first
+type dashboardSource func() (map[string][]*monitor.Status, error)
+
+// dashboardData carries a refresh of the dashboard source
+type dashboardData struct {
+	history map[string][]*monitor.Status
+	err     error
+}
+
+// dashboardTick asks for the next refresh
+type dashboardTick struct{}
+
+// dashboardModel is the bubbletea model of the dashboard
+type dashboardModel struct {
+	source   dashboardSource
+	interval time.Duration
+	mode     string
+	nodes    []string
+
+	history map[string][]*monitor.Status
+	err     error
+	updated time.Time
+	width   int
+}
+
+// runDashboard runs the dashboard until the user quits
+func runDashboard() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	model := dashboardModel{
+		interval: time.Duration(cfg.Monitoring.CheckInterval) * time.Second,
+		mode:     "history",
+		source:   historySource(cfg),
+		width:    80,
+	}
+	for _, node := range cfg.Node {
+		model.nodes = append(model.nodes, node.Name)
+	}
+
+	if dashboardLive {
+		check, closeClients, err := liveStatus()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer closeClients()
+		model.mode = "live"
+		model.source = liveSource(check)
+	}
+
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Printf("Error running dashboard: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// historySource reads the recent checks from the history file of cfg
+func historySource(cfg *config.Config) dashboardSource {
+	return func() (map[string][]*monitor.Status, error) {
+		statuses, err := monitor.LoadHistory(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		history := make(map[string][]*monitor.Status)
+		for _, status := range statuses {
+			history[status.Node] = append(history[status.Node], status)
+		}
+		for node, checks := range history {
+			if len(checks) > dashboardPoints {
+				history[node] = checks[len(checks)-dashboardPoints:]
+			}
+		}
+		return history, nil
+	}
+}
+
+// liveSource checks the nodes on every refresh and keeps the recent checks
+func liveSource(check func() (map[string]*monitor.Status, error)) dashboardSource {
+	history := make(map[string][]*monitor.Status)
+	return func() (map[string][]*monitor.Status, error) {
+		statuses, err := check()
+		if err != nil {
+			return history, err
+		}
+		for node, status := range statuses {
+			checks := append(history[node], status)
+			if len(checks) > dashboardPoints {
+				checks = checks[len(checks)-dashboardPoints:]
+			}
+			history[node] = checks
+		}
+		return history, nil
+	}
+}
+
+// refresh reads the source in the background
+func (m dashboardModel) refresh() tea.Cmd {
+	return func() tea.Msg {
+		history, err := m.source()
+		return dashboardData{history: history, err: err}
+	}
+}
+
+// Init starts the first refresh
+func (m dashboardModel) Init() tea.Cmd {
+	return m.refresh()
+}
+
+// Update handles key presses, resizes and refreshes
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case dashboardData:
+		if msg.history != nil {
+			m.history = msg.history
+		}
+		m.err = msg.err
+		m.updated = time.Now()
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return dashboardTick{} })
+	case dashboardTick:
+		return m, m.refresh()
+	}
+	return m, nil
+}
+
+// View renders the dashboard
+func (m dashboardModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "🔭 Celestia Watchtower (%s)", m.mode)
+	if !m.updated.IsZero() {
+		fmt.Fprintf(&b, "  updated %s, every %s", m.updated.Format("15:04:05"), m.interval)
+	}
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "❌ %v\n\n", m.err)
+	}
+
+	// Labels and values take about 36 columns, the rest is sparkline
+	sparkWidth := max(m.width-36, 10)
+
+	for _, node := range m.nodes {
+		checks := m.history[node]
+		if len(checks) == 0 {
+			fmt.Fprintf(&b, "[%s] waiting for the first check...\n\n", node)
+			continue
+		}
+		last := checks[len(checks)-1]
+
+		health := "✅ HEALTHY"
+		if !last.Healthy {
+			health = "❌ UNHEALTHY"
+			if last.Severity == monitor.SeverityWarning {
+				health = "⚠️ WARNING"
+			}
+		}
+		fmt.Fprintf(&b, "[%s] %s  checked %s\n", node, health, last.Timestamp.Format("15:04:05"))
+		fmt.Fprintf(&b, "  Height: %d/%d (%s)  Peers: %d  NAT: %s\n",
+			last.LocalHeight, last.NetworkHeight, last.HeightSummary(), last.PeerCount, last.NATStatus)
+
+		diffs := make([]float64, len(checks))
+		ins := make([]float64, len(checks))
+		outs := make([]float64, len(checks))
+		for i, status := range checks {
+			diffs[i] = float64(status.BlocksBehind())
+			ins[i] = status.Bandwidth.RateIn / 1024
+			outs[i] = status.Bandwidth.RateOut / 1024
+		}
+		fmt.Fprintf(&b, "  Height diff   %s %d blocks\n", sparkline(diffs, sparkWidth), last.HeightDiff)
+		fmt.Fprintf(&b, "  Bandwidth in  %s %.1f KB/s\n", sparkline(ins, sparkWidth), ins[len(ins)-1])
+		fmt.Fprintf(&b, "  Bandwidth out %s %.1f KB/s\n\n", sparkline(outs, sparkWidth), outs[len(outs)-1])
+	}
+
+	b.WriteString("Press q to quit\n")
+	return b.String()
+}
+
+// sparkline renders the last width values as bars scaled to their maximum
+func sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	highest := 0.0
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > 0 {
+			level = int(v / highest * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	// Pad so the values after the sparkline line up
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/monitor"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes returned by the healthcheck command
+const (
+	healthcheckHealthy   = 0
+	healthcheckUnhealthy = 1
+	healthcheckStale     = 2
+)
+
+var healthcheckMaxAge time.Duration
+
+// healthcheckCmd represents the healthcheck command
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Short: "Exit with a code describing node health",
+	Long: `Check the last recorded status and exit with a code suitable for liveness probes:
+  0  all nodes are healthy
+  1  at least one node is unhealthy
+  2  the status file is missing or older than --max-age`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(runHealthcheck())
+	},
+}
+
+func init() {
+	healthcheckCmd.Flags().DurationVar(&healthcheckMaxAge, "max-age", 5*time.Minute, "Treat a status older than this as stale (0 disables)")
+	rootCmd.AddCommand(healthcheckCmd)
+}
+
+// runHealthcheck evaluates the status file and returns the exit code
+func runHealthcheck() int {
+	// The status file may be moved in the config, the default is used without one
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	statuses, err := monitor.LoadStatus(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "STALE: %v\n", err)
+		return healthcheckStale
+	}
+
+	if len(statuses) == 0 {
+		fmt.Fprintln(os.Stderr, "STALE: status file contains no nodes")
+		return healthcheckStale
+	}
+
+	var stale, unhealthy []string
+	for name, status := range statuses {
+		if healthcheckMaxAge > 0 && time.Since(status.Timestamp) > healthcheckMaxAge {
+			stale = append(stale, name)
+		}
+		if !status.Healthy {
+			unhealthy = append(unhealthy, name)
+		}
+	}
+	sort.Strings(stale)
+	sort.Strings(unhealthy)
+
+	if len(stale) > 0 {
+		fmt.Fprintf(os.Stderr, "STALE: status older than %s for %s\n", healthcheckMaxAge, strings.Join(stale, ", "))
+		return healthcheckStale
+	}
+
+	if len(unhealthy) > 0 {
+		fmt.Fprintf(os.Stderr, "UNHEALTHY: %s\n", strings.Join(unhealthy, ", "))
+		return healthcheckUnhealthy
+	}
+
+	fmt.Fprintf(os.Stderr, "HEALTHY: %d node(s) healthy\n", len(statuses))
+	return healthcheckHealthy
+}
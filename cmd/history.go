@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/monitor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyJSON  bool
+	historyLimit int
+	historyNode  string
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recent node checks",
+	Long:  `Show the most recent checks recorded by a running 'celestia-watchtower start' process.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runHistory()
+	},
+}
+
+func init() {
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Print the checks as JSON")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of checks to show (0 shows all)")
+	historyCmd.Flags().StringVar(&historyNode, "node", "", "Only show checks of this node")
+	rootCmd.AddCommand(historyCmd)
+}
+
+// runHistory prints the last checks from the history file
+func runHistory() {
+	// The history file follows the status file of the config, or the default without one
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	history, err := monitor.LoadHistory(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if historyNode != "" {
+		var filtered []*monitor.Status
+		for _, status := range history {
+			if status.Node == historyNode {
+				filtered = append(filtered, status)
+			}
+		}
+		history = filtered
+	}
+
+	if historyLimit > 0 && len(history) > historyLimit {
+		history = history[len(history)-historyLimit:]
+	}
+
+	if historyJSON {
+		data, err := json.MarshalIndent(history, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding history: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tNODE\tSTATUS\tHEIGHT\tBEHIND\tPEERS\tNAT")
+	for _, status := range history {
+		health := "OK"
+		if !status.Healthy {
+			health = "UNHEALTHY"
+			if status.Severity == monitor.SeverityWarning {
+				health = "WARNING"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%d\t%d\t%s\n",
+			status.Timestamp.Format("2006-01-02 15:04:05"),
+			status.Node,
+			health,
+			status.LocalHeight,
+			status.NetworkHeight,
+			status.HeightDiff,
+			status.PeerCount,
+			status.NATStatus)
+	}
+	w.Flush()
+}
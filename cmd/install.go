@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installSystem bool
+	installPrint  bool
+)
+
+// installCmd represents the install command
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the watchtower as a systemd service",
+	Long: `Generate a systemd unit that runs 'celestia-watchtower start' with the current binary and configuration,
+and install it as a user service in ~/.config/systemd/user, or as a system service in /etc/systemd/system
+with --system. Use --print to only print the unit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runInstall()
+	},
+}
+
+func init() {
+	installCmd.Flags().BoolVar(&installSystem, "system", false, "Install a system service instead of a user service (needs root)")
+	installCmd.Flags().BoolVar(&installPrint, "print", false, "Print the unit instead of installing it")
+	rootCmd.AddCommand(installCmd)
+}
+
+// runInstall generates and installs the systemd unit
+func runInstall() {
+	binary, err := os.Executable()
+	if err == nil {
+		binary, err = filepath.EvalSymlinks(binary)
+	}
+	if err != nil {
+		fmt.Printf("Error finding the watchtower binary: %v\n", err)
+		os.Exit(1)
+	}
+
+	// A system service runs as the invoking user, not as root under sudo
+	serviceUser, err := installUser()
+	if err != nil {
+		fmt.Printf("Error finding the service user: %v\n", err)
+		os.Exit(1)
+	}
+
+	unit := serviceUnit(binary, serviceUser)
+	if installPrint {
+		fmt.Print(unit)
+		return
+	}
+
+	configFile, err := config.ConfigFile()
+	if err != nil {
+		fmt.Printf("Error getting config file path: %v\n", err)
+		os.Exit(1)
+	}
+	if !installSystem {
+		if _, err := os.Stat(configFile); os.IsNotExist(err) {
+			fmt.Println("⚠️  Configuration file not found, run 'celestia-watchtower setup' before starting the service.")
+		}
+	}
+
+	dir := systemServiceDir
+	if !installSystem {
+		if dir, err = userServiceDir(); err != nil {
+			fmt.Printf("Error getting the systemd user directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	serviceFile := filepath.Join(dir, serviceName)
+
+	// Ask before replacing a unit, and point out one installed elsewhere
+	if existing, err := findServiceFile(); err == nil && existing != serviceFile {
+		fmt.Printf("⚠️  A service file already exists at %s\n", existing)
+	}
+	if _, err := os.Stat(serviceFile); err == nil {
+		reader := bufio.NewReader(os.Stdin)
+		if !promptBool(reader, fmt.Sprintf("%s already exists. Overwrite it?", serviceFile), false) {
+			fmt.Println("Service file left unchanged.")
+			return
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Printf("Error creating %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(serviceFile, []byte(unit), 0644); err != nil {
+		fmt.Printf("Error writing service file: %v\n", err)
+		if installSystem && os.IsPermission(err) {
+			fmt.Println("Installing a system service needs root, try again with sudo.")
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Service file written to %s\n\n", serviceFile)
+	fmt.Println("Enable and start the service with:")
+	if installSystem {
+		fmt.Println("  sudo systemctl daemon-reload")
+		fmt.Printf("  sudo systemctl enable --now %s\n", serviceName)
+		fmt.Println("\nFollow its logs with:")
+		fmt.Printf("  journalctl -u %s -f\n", serviceName)
+	} else {
+		fmt.Println("  systemctl --user daemon-reload")
+		fmt.Printf("  systemctl --user enable --now %s\n", serviceName)
+		fmt.Println("\nKeep it running after you log out with:")
+		fmt.Printf("  loginctl enable-linger %s\n", serviceUser.Username)
+		fmt.Println("\nFollow its logs with:")
+		fmt.Printf("  journalctl --user -u %s -f\n", serviceName)
+	}
+}
+
+// installUser returns the user the service runs as, the user behind sudo
+// if there is one
+func installUser() (*user.User, error) {
+	if name := os.Getenv("SUDO_USER"); name != "" {
+		return user.Lookup(name)
+	}
+	return user.Current()
+}
+
+// serviceUnit returns the systemd unit running the watchtower. The
+// configuration is read from the service user's home directory.
+func serviceUnit(binary string, serviceUser *user.User) string {
+	var b strings.Builder
+
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=Celestia Watchtower\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n\n")
+
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=simple\n")
+	fmt.Fprintf(&b, "ExecStart=%s start\n", binary)
+	b.WriteString("ExecReload=/bin/kill -HUP $MAINPID\n")
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString("RestartSec=10\n")
+	if installSystem {
+		fmt.Fprintf(&b, "User=%s\n", serviceUser.Username)
+		fmt.Fprintf(&b, "Environment=HOME=%s\n", serviceUser.HomeDir)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("[Install]\n")
+	if installSystem {
+		b.WriteString("WantedBy=multi-user.target\n")
+	} else {
+		b.WriteString("WantedBy=default.target\n")
+	}
+
+	return b.String()
+}
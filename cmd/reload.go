@@ -16,7 +16,8 @@ import (
 var reloadCmd = &cobra.Command{
 	Use:   "reload",
 	Short: "Reload the watchtower configuration",
-	Long:  `Reload the watchtower configuration and restart the service if running as a systemd service.`,
+	Long: `Validate the watchtower configuration and, if running as a systemd service, send it SIGHUP so the
+running watchtower applies the new configuration without restarting.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runReload()
 	},
@@ -42,31 +43,39 @@ func runReload() {
 	}
 
 	// Try to reload the config to validate it
-	_, err = config.LoadConfig()
+	cfg, err := config.LoadConfig()
 	if err != nil {
 		fmt.Printf("Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
 
+	// The watchtower rejects an invalid config, so report it here
+	if errs := config.Validate(cfg); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Printf("Invalid configuration: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
 	fmt.Println("Configuration loaded successfully.")
 
 	// Check if running as a systemd service
 	if isRunningAsSystemd() {
-		fmt.Println("Detected running as a systemd service. Restarting service...")
-		
-		// Restart the service
-		cmd := exec.Command("systemctl", "restart", "celestia-watchtower.service")
+		fmt.Println("Detected running as a systemd service. Reloading service...")
+
+		// The running watchtower reloads its configuration on SIGHUP
+		cmd := exec.Command("systemctl", "kill", "--signal=HUP", serviceName)
 		err := cmd.Run()
 		if err != nil {
-			fmt.Printf("Error restarting service: %v\n", err)
+			fmt.Printf("Error reloading service: %v\n", err)
 			fmt.Println("You may need to restart the service manually.")
 			os.Exit(1)
 		}
-		
-		fmt.Println("Service restarted successfully.")
+
+		fmt.Println("Service reloaded successfully. Check the service logs for the applied changes.")
 	} else {
 		fmt.Println("Not running as a systemd service.")
-		fmt.Println("Please restart the watchtower manually with 'celestia-watchtower start'.")
+		fmt.Println("Send SIGHUP to the running watchtower (kill -HUP <pid>) to apply the new configuration.")
 	}
 }
 
@@ -88,13 +97,28 @@ func isRunningAsSystemd() bool {
 	return strings.Contains(cmdline, "systemd")
 }
 
+// serviceName is the name of the watchtower's systemd unit
+const serviceName = "celestia-watchtower.service"
+
+// systemServiceDir is where system-wide units are installed
+const systemServiceDir = "/etc/systemd/system"
+
+// userServiceDir returns the directory of the user's systemd units
+func userServiceDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config/systemd/user"), nil
+}
+
 // findServiceFile tries to find the systemd service file
 func findServiceFile() (string, error) {
 	// Common locations for systemd service files
 	locations := []string{
-		"/etc/systemd/system/celestia-watchtower.service",
-		"/lib/systemd/system/celestia-watchtower.service",
-		"/usr/lib/systemd/system/celestia-watchtower.service",
+		filepath.Join(systemServiceDir, serviceName),
+		filepath.Join("/lib/systemd/system", serviceName),
+		filepath.Join("/usr/lib/systemd/system", serviceName),
 	}
 
 	// Check if the service file exists in any of the locations
@@ -105,14 +129,12 @@ func findServiceFile() (string, error) {
 	}
 
 	// Check in user's systemd directory
-	homeDir, err := os.UserHomeDir()
+	userDir, err := userServiceDir()
 	if err != nil {
 		return "", err
 	}
 
-	userServiceDir := filepath.Join(homeDir, ".config/systemd/user")
-	userServiceFile := filepath.Join(userServiceDir, "celestia-watchtower.service")
-	
+	userServiceFile := filepath.Join(userDir, serviceName)
 	if _, err := os.Stat(userServiceFile); err == nil {
 		return userServiceFile, nil
 	}
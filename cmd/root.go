@@ -12,8 +12,16 @@ var (
 		Long: `Celestia Watchtower is a monitoring tool for Celestia nodes.
 It checks the node's status periodically and sends alerts if issues are detected.`,
 	}
+
+	// noColor turns off colored output, which is also off when NO_COLOR is
+	// set or stdout is not a terminal
+	noColor bool
 )
 
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+}
+
 // Execute executes the root command
 func Execute() error {
 	return rootCmd.Execute()
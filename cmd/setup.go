@@ -30,6 +30,7 @@ func runSetup() {
 	fmt.Println("🔧 Celestia Watchtower Setup")
 	fmt.Println("This wizard will help you configure the watchtower.")
 	fmt.Println("Press Enter to accept the default values shown in [brackets].")
+	fmt.Println("Tokens and webhook URLs can be given as ${ENV_VAR} to read them from the environment.")
 	fmt.Println()
 
 	// Load default config
@@ -40,23 +41,90 @@ func runSetup() {
 
 	// Node settings
 	fmt.Println("📡 Node Settings")
-	cfg.Node.RPCEndpoint = promptString(reader, "RPC Endpoint", cfg.Node.RPCEndpoint)
-	cfg.Node.AuthToken = promptString(reader, "Auth Token", cfg.Node.AuthToken)
+	node := &cfg.Node[0]
+	if node.Label == "" {
+		node.Label, _ = os.Hostname()
+	}
+	node.Label = promptString(reader, "Node Label (shown in alerts)", node.Label)
+	node.RPCEndpoint = promptString(reader, "RPC Endpoint", node.RPCEndpoint)
+	if strings.HasPrefix(node.RPCEndpoint, "https://") {
+		node.TLS.CACertPath = promptString(reader, "CA Certificate Path (empty to use system CAs)", node.TLS.CACertPath)
+		node.TLS.ClientCertPath = promptString(reader, "Client Certificate Path (empty to skip mutual TLS)", node.TLS.ClientCertPath)
+		if node.TLS.ClientCertPath != "" {
+			node.TLS.ClientKeyPath = promptString(reader, "Client Key Path", node.TLS.ClientKeyPath)
+		}
+	}
+	node.AuthToken = promptString(reader, "Auth Token", node.AuthToken)
+	fallbacks := promptString(reader, "Fallback RPC Endpoints of the same node (comma separated, empty for none)", strings.Join(node.FallbackEndpoints, ","))
+	node.FallbackEndpoints = splitList(fallbacks)
+	node.DataDir = promptString(reader, "Node Data Directory (empty to skip disk checks)", node.DataDir)
+	node.StorePath = promptString(reader, "Node Store Directory to track the size of (empty to skip)", node.StorePath)
+	node.ProcessUnit = promptString(reader, "Node systemd Unit to track the CPU and memory of (empty to skip)", node.ProcessUnit)
+	node.Type = promptString(reader, "Node Type (auto, light, full, bridge)", node.Type)
+	node.ExpectedChainID = promptString(reader, "Expected Chain ID, e.g. celestia or mocha-4 (empty to skip the check)", node.ExpectedChainID)
+	checkpoints := promptString(reader, "Trusted Checkpoints as height:hash (comma separated, empty to skip the check)", strings.Join(node.Checkpoints, ","))
+	node.Checkpoints = splitList(checkpoints)
+	fmt.Println("To monitor more nodes, list them under 'node' in the config file.")
 	fmt.Println()
 
 	// Monitoring settings
 	fmt.Println("⏱️ Monitoring Settings")
 	checkInterval := promptInt(reader, "Check Interval (seconds)", cfg.Monitoring.CheckInterval)
 	cfg.Monitoring.CheckInterval = checkInterval
+	cfg.Monitoring.JitterSeconds = promptInt(reader, "Check Jitter (seconds, 0 to disable)", cfg.Monitoring.JitterSeconds)
+	cfg.Monitoring.InfluxDB.Enabled = promptBool(reader, "Export Checks to InfluxDB", cfg.Monitoring.InfluxDB.Enabled)
+	if cfg.Monitoring.InfluxDB.Enabled {
+		cfg.Monitoring.InfluxDB.URL = promptString(reader, "InfluxDB URL", cfg.Monitoring.InfluxDB.URL)
+		cfg.Monitoring.InfluxDB.Org = promptString(reader, "InfluxDB Organization", cfg.Monitoring.InfluxDB.Org)
+		cfg.Monitoring.InfluxDB.Bucket = promptString(reader, "InfluxDB Bucket", cfg.Monitoring.InfluxDB.Bucket)
+		cfg.Monitoring.InfluxDB.Token = promptString(reader, "InfluxDB Token", cfg.Monitoring.InfluxDB.Token)
+	}
 	fmt.Println()
 
 	// Threshold settings
 	fmt.Println("🎚️ Threshold Settings")
+	cfg.Thresholds.SyncStatus.BlocksBehindWarning = promptInt(reader, "Warning Blocks Behind (0 to disable)", cfg.Thresholds.SyncStatus.BlocksBehindWarning)
 	blocksBehind := promptInt(reader, "Critical Blocks Behind", cfg.Thresholds.SyncStatus.BlocksBehindCritical)
 	cfg.Thresholds.SyncStatus.BlocksBehindCritical = blocksBehind
 
+	cfg.Thresholds.SyncStatus.StallTimeoutSeconds = promptInt(reader, "Sync Stall Timeout (seconds, 0 to disable)", cfg.Thresholds.SyncStatus.StallTimeoutSeconds)
+	cfg.Thresholds.SyncStatus.MaxHeadAgeSeconds = promptInt(reader, "Max Network Head Age (seconds, 0 to disable)", cfg.Thresholds.SyncStatus.MaxHeadAgeSeconds)
+	cfg.Thresholds.Network.MinPeersWarning = promptInt(reader, "Warn Below Peers (0 to disable)", cfg.Thresholds.Network.MinPeersWarning)
 	minPeers := promptInt(reader, "Minimum Healthy Peers", cfg.Thresholds.Network.MinPeersHealthy)
 	cfg.Thresholds.Network.MinPeersHealthy = minPeers
+	cfg.Thresholds.Network.PeerSmoothingChecks = promptInt(reader, "Average Peer Count Over Checks (0 to disable)", cfg.Thresholds.Network.PeerSmoothingChecks)
+	cfg.Thresholds.Network.PeerHysteresis = promptInt(reader, "Peers Above Minimum To Recover", cfg.Thresholds.Network.PeerHysteresis)
+	cfg.Thresholds.Network.RequireInbound = promptBool(reader, "Alert When No Peer Connects Inbound", cfg.Thresholds.Network.RequireInbound)
+	cfg.Thresholds.Network.NATChangeAlert = promptString(reader, "Alert On NAT Status Changes (off, any, from_public)", cfg.Thresholds.Network.NATChangeAlert)
+
+	cfg.Thresholds.Disk.MinFreePercent = float64(promptInt(reader, "Minimum Free Disk (%)", int(cfg.Thresholds.Disk.MinFreePercent)))
+	if node.StorePath != "" {
+		cfg.Thresholds.Disk.MaxStoreSizeGB = promptInt(reader, "Max Node Store Size in GB (0 to disable)", cfg.Thresholds.Disk.MaxStoreSizeGB)
+	}
+	cfg.Thresholds.Bandwidth.MinRateInBytes = promptInt(reader, "Min Inbound Bandwidth in bytes/s (0 to disable)", cfg.Thresholds.Bandwidth.MinRateInBytes)
+	cfg.Thresholds.Bandwidth.MinRateOutBytes = promptInt(reader, "Min Outbound Bandwidth in bytes/s (0 to disable)", cfg.Thresholds.Bandwidth.MinRateOutBytes)
+	if cfg.Thresholds.Bandwidth.MinRateInBytes > 0 || cfg.Thresholds.Bandwidth.MinRateOutBytes > 0 {
+		cfg.Thresholds.Bandwidth.LowChecks = promptInt(reader, "Alert After Consecutive Low Bandwidth Checks", cfg.Thresholds.Bandwidth.LowChecks)
+	}
+	cfg.Thresholds.Sampling.MaxBehind = promptInt(reader, "Max DAS Sampling Lag in headers (0 to disable)", cfg.Thresholds.Sampling.MaxBehind)
+	if cfg.Thresholds.Sampling.MaxBehind > 0 {
+		cfg.Thresholds.Sampling.HeadersBehindCritical = promptInt(reader, "Critical DAS Sampling Lag in headers (0 for twice the max)", cfg.Thresholds.Sampling.HeadersBehindCritical)
+	}
+	cfg.Thresholds.Resources.MaxMemoryMB = promptInt(reader, "Max Node libp2p Memory in MB (0 to disable)", cfg.Thresholds.Resources.MaxMemoryMB)
+	if node.ProcessUnit != "" || node.ProcessPIDFile != "" {
+		cfg.Thresholds.Process.MaxCPUPercent = float64(promptInt(reader, "Max Node Process CPU in % of one core (0 to disable)", int(cfg.Thresholds.Process.MaxCPUPercent)))
+		cfg.Thresholds.Process.MaxMemoryMB = promptInt(reader, "Max Node Process Memory in MB (0 to disable)", cfg.Thresholds.Process.MaxMemoryMB)
+	}
+	cfg.Thresholds.RPC.MaxLatencyMs = promptInt(reader, "Max Average Check Duration in ms (0 to disable)", cfg.Thresholds.RPC.MaxLatencyMs)
+
+	// Blob submission check
+	fmt.Println("⚠️  The blob submission check pays for a small blob from the node's account every interval.")
+	cfg.Thresholds.Blob.Enabled = promptBool(reader, "Enable Blob Submission Check (spends tokens)", cfg.Thresholds.Blob.Enabled)
+	if cfg.Thresholds.Blob.Enabled {
+		cfg.Thresholds.Blob.Namespace = promptString(reader, "Blob Namespace (up to 10 characters)", cfg.Thresholds.Blob.Namespace)
+		cfg.Thresholds.Blob.MaxSubmitSeconds = promptInt(reader, "Max Blob Submit Time (seconds)", cfg.Thresholds.Blob.MaxSubmitSeconds)
+		cfg.Thresholds.Blob.IntervalMinutes = promptInt(reader, "Blob Submission Interval (minutes)", cfg.Thresholds.Blob.IntervalMinutes)
+	}
 	fmt.Println()
 
 	// Alert settings
@@ -65,13 +133,38 @@ func runSetup() {
 	cfg.Alerts.Enabled = enableAlerts
 
 	if enableAlerts {
+		cfg.Alerts.AlertCooldownMinutes = promptInt(reader, "Alert Cooldown (minutes)", cfg.Alerts.AlertCooldownMinutes)
+		cfg.Alerts.NotifyRecovery = promptBool(reader, "Notify when a node recovers", cfg.Alerts.NotifyRecovery)
+		cfg.Alerts.UnreachableAfterChecks = promptInt(reader, "Alert that a node is unreachable after consecutive failed checks", cfg.Alerts.UnreachableAfterChecks)
+		cfg.Alerts.ReminderIntervalMinutes = promptInt(reader, "Remind of a node still unhealthy every (minutes, 0 to disable)", cfg.Alerts.ReminderIntervalMinutes)
+		cfg.Alerts.EscalateAfterMinutes = promptInt(reader, "Escalate after unhealthy for (minutes, 0 to disable)", cfg.Alerts.EscalateAfterMinutes)
+		cfg.Alerts.EscalateAfterChecks = promptInt(reader, "Escalate after consecutive unhealthy checks (0 to disable)", cfg.Alerts.EscalateAfterChecks)
+		if cfg.Alerts.EscalateAfterMinutes > 0 || cfg.Alerts.EscalateAfterChecks > 0 {
+			channels := promptString(reader, "Escalation Channels (comma separated, e.g. twilio,pagerduty)", strings.Join(cfg.Alerts.EscalationChannels, ","))
+			cfg.Alerts.EscalationChannels = splitList(channels)
+		}
+		cfg.Alerts.Digest.Enabled = promptBool(reader, "Send a periodic digest of every node", cfg.Alerts.Digest.Enabled)
+		if cfg.Alerts.Digest.Enabled {
+			cfg.Alerts.Digest.IntervalHours = promptInt(reader, "Digest Interval (hours, e.g. 1 or 24)", cfg.Alerts.Digest.IntervalHours)
+			channels := promptString(reader, "Digest Channels (comma separated, e.g. discord)", strings.Join(cfg.Alerts.Digest.Channels, ","))
+			cfg.Alerts.Digest.Channels = splitList(channels)
+			cfg.Alerts.Digest.Exclusive = promptBool(reader, "Send only digests to the digest channels", cfg.Alerts.Digest.Exclusive)
+		}
+
 		// Telegram alerts
 		enableTelegram := promptBool(reader, "Enable Telegram Alerts", cfg.Alerts.Telegram.Enabled)
 		cfg.Alerts.Telegram.Enabled = enableTelegram
 
 		if enableTelegram {
+			cfg.Alerts.Telegram.MinSeverity = promptString(reader, "Telegram Minimum Severity (info, warning, critical)", cfg.Alerts.Telegram.MinSeverity)
 			cfg.Alerts.Telegram.BotToken = promptString(reader, "Telegram Bot Token", cfg.Alerts.Telegram.BotToken)
-			cfg.Alerts.Telegram.ChatID = promptString(reader, "Telegram Chat ID", cfg.Alerts.Telegram.ChatID)
+			chatIDs := promptString(reader, "Telegram Chat IDs (comma separated)", strings.Join(cfg.Alerts.Telegram.ChatIDs, ","))
+			cfg.Alerts.Telegram.ChatIDs = splitList(chatIDs)
+			if len(cfg.Alerts.Telegram.ChatIDs) > 0 {
+				cfg.Alerts.Telegram.ThreadID = promptInt(reader, "Telegram Topic Thread ID (0 for none)", cfg.Alerts.Telegram.ThreadID)
+			}
+			cfg.Alerts.Telegram.ParseMode = promptString(reader, "Telegram Parse Mode (markdown, html, none)", cfg.Alerts.Telegram.ParseMode)
+			cfg.Alerts.Telegram.Interactive = promptBool(reader, "Enable the Acknowledge Button and /ack, /status Commands in Telegram", cfg.Alerts.Telegram.Interactive)
 		}
 
 		// Discord alerts
@@ -79,7 +172,9 @@ func runSetup() {
 		cfg.Alerts.Discord.Enabled = enableDiscord
 
 		if enableDiscord {
+			cfg.Alerts.Discord.MinSeverity = promptString(reader, "Discord Minimum Severity (info, warning, critical)", cfg.Alerts.Discord.MinSeverity)
 			cfg.Alerts.Discord.Webhook = promptString(reader, "Discord Webhook URL", cfg.Alerts.Discord.Webhook)
+			cfg.Alerts.Discord.Embeds = promptBool(reader, "Send Discord alerts as rich embeds", cfg.Alerts.Discord.Embeds)
 		}
 
 		// Twilio alerts
@@ -87,14 +182,167 @@ func runSetup() {
 		cfg.Alerts.Twilio.Enabled = enableTwilio
 
 		if enableTwilio {
+			cfg.Alerts.Twilio.MinSeverity = promptString(reader, "Twilio Minimum Severity (info, warning, critical)", cfg.Alerts.Twilio.MinSeverity)
 			cfg.Alerts.Twilio.AccountSID = promptString(reader, "Twilio Account SID", cfg.Alerts.Twilio.AccountSID)
 			cfg.Alerts.Twilio.AuthToken = promptString(reader, "Twilio Auth Token", cfg.Alerts.Twilio.AuthToken)
 			cfg.Alerts.Twilio.FromNumber = promptString(reader, "Twilio From Number", cfg.Alerts.Twilio.FromNumber)
-			cfg.Alerts.Twilio.ToNumber = promptString(reader, "Twilio To Number", cfg.Alerts.Twilio.ToNumber)
+			toNumbers := promptString(reader, "Twilio To Numbers (comma separated)", strings.Join(cfg.Alerts.Twilio.ToNumbers, ","))
+			cfg.Alerts.Twilio.ToNumbers = splitList(toNumbers)
+			cfg.Alerts.Twilio.Channel = promptString(reader, "Twilio Channel (sms, whatsapp or both)", cfg.Alerts.Twilio.Channel)
+		}
+
+		// Slack alerts
+		enableSlack := promptBool(reader, "Enable Slack Alerts", cfg.Alerts.Slack.Enabled)
+		cfg.Alerts.Slack.Enabled = enableSlack
+
+		if enableSlack {
+			cfg.Alerts.Slack.MinSeverity = promptString(reader, "Slack Minimum Severity (info, warning, critical)", cfg.Alerts.Slack.MinSeverity)
+			cfg.Alerts.Slack.WebhookURL = promptString(reader, "Slack Webhook URL", cfg.Alerts.Slack.WebhookURL)
+		}
+
+		// Generic webhook alerts
+		enableWebhook := promptBool(reader, "Enable Generic Webhook Alerts", cfg.Alerts.Webhook.Enabled)
+		cfg.Alerts.Webhook.Enabled = enableWebhook
+
+		if enableWebhook {
+			cfg.Alerts.Webhook.MinSeverity = promptString(reader, "Generic Webhook Minimum Severity (info, warning, critical)", cfg.Alerts.Webhook.MinSeverity)
+			cfg.Alerts.Webhook.URL = promptString(reader, "Webhook URL", cfg.Alerts.Webhook.URL)
+			cfg.Alerts.Webhook.Method = strings.ToUpper(promptString(reader, "Webhook HTTP Method", cfg.Alerts.Webhook.Method))
+			cfg.Alerts.Webhook.ContentType = promptString(reader, "Webhook Content-Type", cfg.Alerts.Webhook.ContentType)
+			cfg.Alerts.Webhook.Secret = promptString(reader, "Webhook Signing Secret (empty to send unsigned requests)", cfg.Alerts.Webhook.Secret)
+			fmt.Println("Custom headers and the body template can be edited in the config file.")
+		}
+
+		// Pushover alerts
+		enablePushover := promptBool(reader, "Enable Pushover Alerts", cfg.Alerts.Pushover.Enabled)
+		cfg.Alerts.Pushover.Enabled = enablePushover
+
+		if enablePushover {
+			cfg.Alerts.Pushover.MinSeverity = promptString(reader, "Pushover Minimum Severity (info, warning, critical)", cfg.Alerts.Pushover.MinSeverity)
+			cfg.Alerts.Pushover.UserKey = promptString(reader, "Pushover User Key", cfg.Alerts.Pushover.UserKey)
+			cfg.Alerts.Pushover.AppToken = promptString(reader, "Pushover App Token", cfg.Alerts.Pushover.AppToken)
+			cfg.Alerts.Pushover.Priority = promptInt(reader, "Pushover Priority for unhealthy alerts (-2 to 1)", cfg.Alerts.Pushover.Priority)
+			cfg.Alerts.Pushover.Sound = promptString(reader, "Pushover Sound (empty for default)", cfg.Alerts.Pushover.Sound)
+		}
+
+		// Microsoft Teams alerts
+		enableTeams := promptBool(reader, "Enable Microsoft Teams Alerts", cfg.Alerts.Teams.Enabled)
+		cfg.Alerts.Teams.Enabled = enableTeams
+
+		if enableTeams {
+			cfg.Alerts.Teams.MinSeverity = promptString(reader, "Microsoft Teams Minimum Severity (info, warning, critical)", cfg.Alerts.Teams.MinSeverity)
+			cfg.Alerts.Teams.WebhookURL = promptString(reader, "Teams Webhook URL", cfg.Alerts.Teams.WebhookURL)
+		}
+
+		// AWS SNS alerts
+		enableSNS := promptBool(reader, "Enable AWS SNS Alerts", cfg.Alerts.SNS.Enabled)
+		cfg.Alerts.SNS.Enabled = enableSNS
+
+		if enableSNS {
+			cfg.Alerts.SNS.MinSeverity = promptString(reader, "AWS SNS Minimum Severity (info, warning, critical)", cfg.Alerts.SNS.MinSeverity)
+			cfg.Alerts.SNS.Region = promptString(reader, "AWS Region", cfg.Alerts.SNS.Region)
+			cfg.Alerts.SNS.TopicARN = promptString(reader, "SNS Topic ARN (empty to send SMS directly)", cfg.Alerts.SNS.TopicARN)
+			if cfg.Alerts.SNS.TopicARN == "" {
+				cfg.Alerts.SNS.PhoneNumber = promptString(reader, "SMS Phone Number (e.g. +15551234567)", cfg.Alerts.SNS.PhoneNumber)
+			}
+			fmt.Println("Leave the AWS keys empty to use the default AWS credential chain.")
+			cfg.Alerts.SNS.AccessKeyID = promptString(reader, "AWS Access Key ID", cfg.Alerts.SNS.AccessKeyID)
+			cfg.Alerts.SNS.SecretAccessKey = promptString(reader, "AWS Secret Access Key", cfg.Alerts.SNS.SecretAccessKey)
+		}
+
+		// PagerDuty alerts
+		enablePagerDuty := promptBool(reader, "Enable PagerDuty Alerts", cfg.Alerts.PagerDuty.Enabled)
+		cfg.Alerts.PagerDuty.Enabled = enablePagerDuty
+
+		if enablePagerDuty {
+			cfg.Alerts.PagerDuty.MinSeverity = promptString(reader, "PagerDuty Minimum Severity (info, warning, critical)", cfg.Alerts.PagerDuty.MinSeverity)
+			cfg.Alerts.PagerDuty.RoutingKey = promptString(reader, "PagerDuty Routing Key", cfg.Alerts.PagerDuty.RoutingKey)
+		}
+
+		// Webex alerts
+		enableWebex := promptBool(reader, "Enable Cisco Webex Alerts", cfg.Alerts.Webex.Enabled)
+		cfg.Alerts.Webex.Enabled = enableWebex
+
+		if enableWebex {
+			cfg.Alerts.Webex.MinSeverity = promptString(reader, "Cisco Webex Minimum Severity (info, warning, critical)", cfg.Alerts.Webex.MinSeverity)
+			cfg.Alerts.Webex.BotToken = promptString(reader, "Webex Bot Token", cfg.Alerts.Webex.BotToken)
+			cfg.Alerts.Webex.RoomID = promptString(reader, "Webex Room ID", cfg.Alerts.Webex.RoomID)
+		}
+
+		// XMPP alerts
+		enableXMPP := promptBool(reader, "Enable XMPP (Jabber) Alerts", cfg.Alerts.XMPP.Enabled)
+		cfg.Alerts.XMPP.Enabled = enableXMPP
+
+		if enableXMPP {
+			cfg.Alerts.XMPP.MinSeverity = promptString(reader, "XMPP (Jabber) Minimum Severity (info, warning, critical)", cfg.Alerts.XMPP.MinSeverity)
+			cfg.Alerts.XMPP.JID = promptString(reader, "XMPP JID", cfg.Alerts.XMPP.JID)
+			cfg.Alerts.XMPP.Password = promptString(reader, "XMPP Password", cfg.Alerts.XMPP.Password)
+			cfg.Alerts.XMPP.Server = promptString(reader, "XMPP Server (host:port, empty for JID domain)", cfg.Alerts.XMPP.Server)
+			cfg.Alerts.XMPP.Recipient = promptString(reader, "XMPP Recipient JID", cfg.Alerts.XMPP.Recipient)
+		}
+
+		// MQTT alerts
+		enableMQTT := promptBool(reader, "Enable MQTT Alerts", cfg.Alerts.MQTT.Enabled)
+		cfg.Alerts.MQTT.Enabled = enableMQTT
+
+		if enableMQTT {
+			cfg.Alerts.MQTT.MinSeverity = promptString(reader, "MQTT Minimum Severity (info, warning, critical)", cfg.Alerts.MQTT.MinSeverity)
+			cfg.Alerts.MQTT.BrokerURL = promptString(reader, "MQTT Broker URL", cfg.Alerts.MQTT.BrokerURL)
+			cfg.Alerts.MQTT.Username = promptString(reader, "MQTT Username (empty for none)", cfg.Alerts.MQTT.Username)
+			cfg.Alerts.MQTT.Password = promptString(reader, "MQTT Password", cfg.Alerts.MQTT.Password)
+			cfg.Alerts.MQTT.TopicPrefix = promptString(reader, "MQTT Topic Prefix", cfg.Alerts.MQTT.TopicPrefix)
+			cfg.Alerts.MQTT.QoS = byte(promptInt(reader, "MQTT QoS (0-2)", int(cfg.Alerts.MQTT.QoS)))
+			cfg.Alerts.MQTT.Retain = promptBool(reader, "Retain MQTT messages", cfg.Alerts.MQTT.Retain)
+			cfg.Alerts.MQTT.PublishStatus = promptBool(reader, "Publish status after every check", cfg.Alerts.MQTT.PublishStatus)
+		}
+
+		// Kafka alerts
+		enableKafka := promptBool(reader, "Enable Kafka Alerts", cfg.Alerts.Kafka.Enabled)
+		cfg.Alerts.Kafka.Enabled = enableKafka
+
+		if enableKafka {
+			cfg.Alerts.Kafka.MinSeverity = promptString(reader, "Kafka Minimum Severity (info, warning, critical)", cfg.Alerts.Kafka.MinSeverity)
+			brokers := promptString(reader, "Kafka Brokers (comma separated)", strings.Join(cfg.Alerts.Kafka.Brokers, ","))
+			cfg.Alerts.Kafka.Brokers = splitList(brokers)
+			cfg.Alerts.Kafka.Topic = promptString(reader, "Kafka Topic", cfg.Alerts.Kafka.Topic)
+			cfg.Alerts.Kafka.SASLMechanism = promptString(reader, "Kafka SASL Mechanism (plain, scram-sha-256, scram-sha-512, empty for none)", cfg.Alerts.Kafka.SASLMechanism)
+			if cfg.Alerts.Kafka.SASLMechanism != "" {
+				cfg.Alerts.Kafka.Username = promptString(reader, "Kafka Username", cfg.Alerts.Kafka.Username)
+				cfg.Alerts.Kafka.Password = promptString(reader, "Kafka Password", cfg.Alerts.Kafka.Password)
+			}
+			cfg.Alerts.Kafka.TLS = promptBool(reader, "Use TLS for Kafka", cfg.Alerts.Kafka.TLS)
+		}
+
+		// NATS alerts
+		enableNATS := promptBool(reader, "Enable NATS Alerts", cfg.Alerts.NATS.Enabled)
+		cfg.Alerts.NATS.Enabled = enableNATS
+
+		if enableNATS {
+			cfg.Alerts.NATS.MinSeverity = promptString(reader, "NATS Minimum Severity (info, warning, critical)", cfg.Alerts.NATS.MinSeverity)
+			cfg.Alerts.NATS.ServerURL = promptString(reader, "NATS Server URL", cfg.Alerts.NATS.ServerURL)
+			cfg.Alerts.NATS.CredentialsFile = promptString(reader, "NATS Credentials File (empty for none)", cfg.Alerts.NATS.CredentialsFile)
+			cfg.Alerts.NATS.Subject = promptString(reader, "NATS Subject", cfg.Alerts.NATS.Subject)
+			cfg.Alerts.NATS.PublishStatus = promptBool(reader, "Also publish the node status with each alert", cfg.Alerts.NATS.PublishStatus)
+		}
+
+		// Opsgenie alerts
+		enableOpsgenie := promptBool(reader, "Enable Opsgenie Alerts", cfg.Alerts.Opsgenie.Enabled)
+		cfg.Alerts.Opsgenie.Enabled = enableOpsgenie
+
+		if enableOpsgenie {
+			cfg.Alerts.Opsgenie.MinSeverity = promptString(reader, "Opsgenie Minimum Severity (info, warning, critical)", cfg.Alerts.Opsgenie.MinSeverity)
+			cfg.Alerts.Opsgenie.APIKey = promptString(reader, "Opsgenie API Key", cfg.Alerts.Opsgenie.APIKey)
+			cfg.Alerts.Opsgenie.Region = promptString(reader, "Opsgenie Region (us, eu)", cfg.Alerts.Opsgenie.Region)
 		}
 	}
 	fmt.Println()
 
+	// Check the configuration, it is saved either way so it can be fixed later
+	if !printConfigProblems(cfg) {
+		fmt.Println("Run 'celestia-watchtower setup' again or edit the config file, then 'celestia-watchtower validate'.")
+		fmt.Println()
+	}
+
 	// Save config
 	if err := config.SaveConfig(cfg); err != nil {
 		fmt.Printf("Error saving configuration: %v\n", err)
@@ -122,6 +370,17 @@ func promptString(reader *bufio.Reader, prompt, defaultValue string) string {
 	return input
 }
 
+// splitList splits a comma separated list, dropping empty entries
+func splitList(input string) []string {
+	items := []string{}
+	for _, item := range strings.Split(input, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // promptInt prompts the user for an integer value
 func promptInt(reader *bufio.Reader, prompt string, defaultValue int) int {
 	fmt.Printf("%s [%d]: ", prompt, defaultValue)
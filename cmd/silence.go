@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/21state/celestia-watchtower/alert"
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	silenceReason string
+	silenceClear  bool
+)
+
+// silenceCmd represents the silence command
+var silenceCmd = &cobra.Command{
+	Use:   "silence [duration]",
+	Short: "Hold back alerts for a while",
+	Long: `Hold back alerts for the given duration, e.g. "2h" or "30m", while the node is taken down on purpose.
+Checks keep running and are recorded. If a node is still unhealthy when the silence ends, an alert is sent on
+the next check, and a node that recovered meanwhile after being alerted is reported recovered. Without a duration the current silence and active maintenance windows are shown.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSilence(args)
+	},
+}
+
+func init() {
+	silenceCmd.Flags().StringVar(&silenceReason, "reason", "", "Reason shown in the logs while alerts are silenced")
+	silenceCmd.Flags().BoolVar(&silenceClear, "clear", false, "End the current silence")
+	rootCmd.AddCommand(silenceCmd)
+}
+
+// runSilence writes, clears or shows the ad-hoc silence
+func runSilence(args []string) {
+	switch {
+	case silenceClear:
+		if err := alert.ClearSilence(); err != nil {
+			fmt.Printf("Error clearing silence: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("🔔 Alerts are no longer silenced")
+	case len(args) == 1:
+		duration, err := time.ParseDuration(args[0])
+		if err != nil || duration <= 0 {
+			fmt.Printf("Invalid duration %q, use e.g. 30m or 2h\n", args[0])
+			os.Exit(1)
+		}
+
+		until := time.Now().Add(duration)
+		if err := alert.SaveSilence(alert.Silence{Until: until, Reason: silenceReason}); err != nil {
+			fmt.Printf("Error silencing alerts: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("🔕 Alerts silenced until %s\n", until.Format("2006-01-02 15:04:05"))
+	default:
+		showSilence()
+	}
+}
+
+// showSilence prints the current silence and the active maintenance windows
+func showSilence() {
+	now := time.Now()
+	silenced := false
+
+	silence, err := alert.LoadSilence()
+	if err != nil {
+		fmt.Printf("Error loading silence: %v\n", err)
+		os.Exit(1)
+	}
+	if silence != nil && now.Before(silence.Until) {
+		silenced = true
+		fmt.Printf("🔕 Alerts silenced until %s", silence.Until.Format("2006-01-02 15:04:05"))
+		if silence.Reason != "" {
+			fmt.Printf(" (%s)", silence.Reason)
+		}
+		fmt.Println()
+	}
+
+	// The config is only needed for the maintenance windows
+	if cfg, err := config.LoadConfig(); err == nil {
+		for _, window := range cfg.Alerts.MaintenanceWindows {
+			if window.Active(now) {
+				silenced = true
+				fmt.Printf("🔕 Maintenance window %q is active (%s - %s)\n", window.Name, window.Start, window.End)
+			}
+		}
+	}
+
+	if !silenced {
+		fmt.Println("🔔 Alerts are not silenced")
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/monitor"
+	"github.com/spf13/cobra"
+)
+
+var snapshotSave bool
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Check the nodes once and print their status as JSON",
+	Long: `Check every configured node once, print the resulting status as JSON and exit.
+No daemon needs to be running. With --save the status is also written to the status file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSnapshot()
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().BoolVar(&snapshotSave, "save", false, "Also write the status to the status file")
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// runSnapshot checks the nodes once and prints the status
+func runSnapshot() {
+	check, closeClients, err := liveStatus()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	statuses, err := check()
+	closeClients()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking node status: %v\n", err)
+		os.Exit(1)
+	}
+
+	printStatusJSON(statuses, true)
+
+	if snapshotSave {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		if err := monitor.SaveStatus(cfg, statuses); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving status: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -2,13 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/logging"
 	"github.com/21state/celestia-watchtower/monitor"
+	"github.com/21state/celestia-watchtower/version"
 	"github.com/spf13/cobra"
 )
 
+var (
+	startDebug    bool
+	startHTTPAddr string
+	startOnce     bool
+)
+
 // startCmd represents the start command
 var startCmd = &cobra.Command{
 	Use:   "start",
@@ -20,38 +29,88 @@ var startCmd = &cobra.Command{
 }
 
 func init() {
+	startCmd.Flags().BoolVar(&startDebug, "debug", false, "Print detailed status after each check")
+	startCmd.Flags().StringVar(&startHTTPAddr, "http-addr", "", "Serve /healthz, /readyz and /status on this address, e.g. :8080")
+	startCmd.Flags().BoolVar(&startOnce, "once", false, "Run a single check and exit with 0 if healthy or 1 if not")
 	rootCmd.AddCommand(startCmd)
 }
 
 // runStart starts the monitoring engine
 func runStart() {
+	// Log in text format until the configured format is known
+	logger, _ := logging.New(os.Stdout, logging.FormatText)
+
+	logger.Info(fmt.Sprintf("Celestia Watchtower %s", version.String()))
+
 	// Load configuration
-	fmt.Println("[INFO] Loading configuration...")
+	logger.Info("Loading configuration...")
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		fmt.Printf("[ERROR] Error loading configuration: %v\n", err)
-		fmt.Println("[INFO] Please run 'celestia-watchtower setup' first.")
+		logger.Error(fmt.Sprintf("Error loading configuration: %v", err), "error", err)
+		logger.Info("Please run 'celestia-watchtower setup' first.")
+		os.Exit(1)
+	}
+
+	output := logging.Output(cfg.Logging.File, cfg.Logging.MaxSizeMB, cfg.Logging.MaxBackups)
+	logger, err = logging.New(output, cfg.Logging.Format)
+	if err != nil {
+		fmt.Printf("[ERROR] Error configuring logging: %v\n", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
+	// Refuse to start with an invalid configuration
+	if errs := config.Validate(cfg); len(errs) > 0 {
+		for _, err := range errs {
+			logger.Error(fmt.Sprintf("Invalid configuration: %v", err), "error", err)
+		}
+		logger.Info("Run 'celestia-watchtower validate' after fixing the config file.")
 		os.Exit(1)
 	}
 
 	// Print configuration details
-	fmt.Println("[INFO] Configuration loaded successfully")
-	fmt.Printf("[INFO] RPC Endpoint: '%s'\n", cfg.Node.RPCEndpoint)
-	fmt.Printf("[INFO] Auth Token: %v\n", cfg.Node.AuthToken != "")
-	fmt.Printf("[INFO] Check Interval: %d seconds\n", cfg.Monitoring.CheckInterval)
+	logger.Info("Configuration loaded successfully")
+	for _, node := range cfg.Node {
+		logger.Info(fmt.Sprintf("[%s] RPC Endpoint: '%s'", node.Name, node.RPCEndpoint), "node", node.Name, "endpoint", node.RPCEndpoint)
+		logger.Info(fmt.Sprintf("[%s] Auth Token: %v", node.Name, node.AuthToken != ""), "node", node.Name, "auth_token", node.AuthToken != "")
+	}
+	logger.Info(fmt.Sprintf("Check Interval: %d seconds", cfg.Monitoring.CheckInterval), "interval_seconds", cfg.Monitoring.CheckInterval)
 
 	// Create monitoring engine
-	fmt.Println("[INFO] Creating monitoring engine...")
-	engine, err := monitor.NewEngine(cfg)
+	logger.Info("Creating monitoring engine...")
+	// Escape codes only go to a terminal, never to JSON logs or a log file
+	color := logging.ColorEnabled(noColor) && cfg.Logging.File == "" && cfg.Logging.Format != logging.FormatJSON
+	engine, err := monitor.New(cfg, monitor.Options{Logger: logger, Debug: startDebug, Color: color})
 	if err != nil {
-		fmt.Printf("[ERROR] Error creating monitoring engine: %v\n", err)
+		logger.Error(fmt.Sprintf("Error creating monitoring engine: %v", err), "error", err)
 		os.Exit(1)
 	}
 
+	// Single check for cron style monitoring
+	if startOnce {
+		healthy, err := engine.RunOnce()
+		engine.Close()
+		if err != nil {
+			logger.Error(fmt.Sprintf("Check failed: %v", err), "error", err)
+		}
+		if !healthy {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Start the health endpoints if requested
+	if startHTTPAddr != "" {
+		if err := engine.StartHTTPServer(startHTTPAddr); err != nil {
+			logger.Error(fmt.Sprintf("Error starting HTTP server: %v", err), "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Start monitoring
-	fmt.Println("[INFO] Starting monitoring engine...")
+	logger.Info("Starting monitoring engine...")
 	if err := engine.Start(); err != nil {
-		fmt.Printf("[ERROR] Error starting monitoring engine: %v\n", err)
+		logger.Error(fmt.Sprintf("Error starting monitoring engine: %v", err), "error", err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/logging"
+	"github.com/21state/celestia-watchtower/monitor"
+	"github.com/21state/celestia-watchtower/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusJSON     bool
+	statusWatch    bool
+	statusLive     bool
+	statusInterval time.Duration
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the last known node status",
+	Long: `Show the node status last recorded by a running 'celestia-watchtower start' process.
+
+With --live the nodes are checked directly instead, so no daemon needs to be running.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runStatus()
+	},
+}
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Print the status as JSON")
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Keep refreshing the status")
+	statusCmd.Flags().BoolVar(&statusLive, "live", false, "Check the nodes directly instead of reading the status file")
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 5*time.Second, "Refresh interval in watch mode")
+	rootCmd.AddCommand(statusCmd)
+}
+
+// runStatus prints the status once or repeatedly in watch mode
+func runStatus() {
+	// The status file and timestamps follow the config, or the defaults without one
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	loadStatus := func() (map[string]*monitor.Status, error) { return monitor.LoadStatus(cfg) }
+	if statusLive {
+		check, closeClients, err := liveStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer closeClients()
+		loadStatus = check
+	}
+
+	if !statusWatch {
+		statuses, err := loadStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading status: %v\n", err)
+			os.Exit(1)
+		}
+
+		if statusJSON {
+			printStatusJSON(statuses, true)
+		} else {
+			printStatus(cfg, statuses)
+		}
+		return
+	}
+
+	var lastUpdate time.Time
+	for {
+		statuses, err := loadStatus()
+		switch {
+		case err != nil && statusJSON:
+			fmt.Fprintf(os.Stderr, "Error loading status: %v\n", err)
+		case err != nil:
+			clearScreen()
+			fmt.Printf("Error loading status: %v\n", err)
+		case statusJSON:
+			// Only emit a new line when the status file has changed
+			if updated := latestTimestamp(statuses); updated.After(lastUpdate) {
+				lastUpdate = updated
+				printStatusJSON(statuses, false)
+			}
+		default:
+			clearScreen()
+			printStatus(cfg, statuses)
+		}
+
+		time.Sleep(statusInterval)
+	}
+}
+
+// liveStatus connects to every configured node and returns a function that
+// checks them directly, along with a function closing the connections
+func liveStatus() (func() (map[string]*monitor.Status, error), func(), error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	clients := make(map[string]*rpc.Client, len(cfg.Node))
+	closeClients := func() {
+		for _, client := range clients {
+			client.Close()
+		}
+		cancel()
+	}
+
+	for _, node := range cfg.Node {
+		client, err := monitor.NewNodeClient(ctx, node, slog.Default())
+		if err != nil {
+			closeClients()
+			return nil, nil, fmt.Errorf("failed to connect to node %q: %w", node.Name, err)
+		}
+		clients[node.Name] = client
+	}
+
+	check := func() (map[string]*monitor.Status, error) {
+		statuses := make(map[string]*monitor.Status, len(cfg.Node))
+		for _, node := range cfg.Node {
+			status, err := monitor.CheckNodeStatus(clients[node.Name], cfg, node)
+			if err != nil {
+				return nil, fmt.Errorf("[%s] %w", node.Name, err)
+			}
+			status.Node = node.Name
+			status.Label = node.Label
+			statuses[node.Name] = status
+		}
+		return statuses, nil
+	}
+
+	return check, closeClients, nil
+}
+
+// printStatusJSON prints the statuses as JSON, one object per line unless indented
+func printStatusJSON(statuses map[string]*monitor.Status, indent bool) {
+	var data []byte
+	var err error
+	if indent {
+		data, err = json.MarshalIndent(statuses, "", "  ")
+	} else {
+		data, err = json.Marshal(statuses)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding status: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(data))
+}
+
+// printStatus prints the statuses in a human readable format
+func printStatus(cfg *config.Config, statuses map[string]*monitor.Status) {
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	color := logging.ColorEnabled(noColor)
+	for _, name := range names {
+		status := statuses[name]
+
+		health := status.ColoredIndicator(color)
+
+		fmt.Printf("📡 Node: %s\n", name)
+		fmt.Printf("   Last Check: %s\n", cfg.FormatTime(status.Timestamp))
+		fmt.Printf("   Status:     %s\n", health)
+		if status.NodeType != "" {
+			fmt.Printf("   Type:       %s %s\n", status.NodeType, status.APIVersion)
+		}
+		fmt.Printf("   Height:     %d/%d (%s)\n", status.LocalHeight, status.NetworkHeight, status.HeightSummary())
+		if status.PeerDetails.Available {
+			fmt.Printf("   Peers:      %d (%d inbound, %d outbound)\n", status.PeerCount, status.PeerDetails.Inbound, status.PeerDetails.Outbound)
+		} else {
+			fmt.Printf("   Peers:      %d\n", status.PeerCount)
+		}
+		if status.PeerCountSmoothed > 0 {
+			fmt.Printf("   Peers Avg:  %.1f\n", status.PeerCountSmoothed)
+		}
+		fmt.Printf("   NAT:        %s\n", status.NATStatus)
+		fmt.Printf("   Bandwidth:  In %.1f KB/s | Out %.1f KB/s\n", status.Bandwidth.RateIn/1024.0, status.Bandwidth.RateOut/1024.0)
+		if process := status.Process; process != nil {
+			fmt.Printf("   Process:    pid %d | CPU %.1f%% | Memory %.1f MB\n", process.PID, process.CPUPercent, float64(process.RSSBytes)/1024.0/1024.0)
+		}
+		for _, err := range status.Errors {
+			fmt.Printf("   Failed:     %s\n", err)
+		}
+		fmt.Println()
+	}
+}
+
+// latestTimestamp returns the most recent check time across all nodes
+func latestTimestamp(statuses map[string]*monitor.Status) time.Time {
+	var latest time.Time
+	for _, status := range statuses {
+		if status.Timestamp.After(latest) {
+			latest = status.Timestamp
+		}
+	}
+	return latest
+}
+
+// clearScreen clears the terminal
+func clearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
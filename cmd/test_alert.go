@@ -6,6 +6,7 @@ import (
 
 	"github.com/21state/celestia-watchtower/alert"
 	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/monitor"
 	"github.com/spf13/cobra"
 )
 
@@ -13,13 +14,25 @@ import (
 var testAlertCmd = &cobra.Command{
 	Use:   "test-alert",
 	Short: "Test alert notifications",
-	Long:  `Send a test alert to verify that alert notifications are working correctly.`,
+	Long: `Send a test alert to verify that alert notifications are working correctly, to every enabled channel or only to the one given with --channel.
+
+Webhook signatures: with alerts.webhook.secret set, every webhook request carries two headers.
+  X-Watchtower-Timestamp  Unix time in seconds the request was signed at
+  X-Watchtower-Signature  "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>", keyed with the secret
+To verify a request, recompute the HMAC over the timestamp header, a dot and the raw request body, compare it
+to the signature in constant time and reject requests whose timestamp is more than a few minutes old.
+
+Webhook templates: test alerts carry a sample status of the first node as .Status. Digests and alerts about
+an unreachable node carry none, so guard its fields with {{with .Status}}...{{end}}.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runTestAlert()
 	},
 }
 
+var testAlertChannel string
+
 func init() {
+	testAlertCmd.Flags().StringVar(&testAlertChannel, "channel", "all", "Channel to test, e.g. telegram, discord or webhook, or all")
 	rootCmd.AddCommand(testAlertCmd)
 }
 
@@ -41,17 +54,30 @@ func runTestAlert() {
 	}
 
 	// Check if at least one alert channel is configured
-	if !cfg.Alerts.Telegram.Enabled && !cfg.Alerts.Discord.Enabled && !cfg.Alerts.Twilio.Enabled {
+	if !cfg.AnyAlertChannelEnabled() {
 		fmt.Println("No alert channels are enabled in the configuration.")
 		fmt.Println("Please configure at least one alert channel with 'celestia-watchtower setup'.")
 		os.Exit(1)
 	}
 
-	fmt.Println("Sending test alert...")
-
 	// Create alert manager and send test alert
-	alerter := alert.NewManager(cfg)
-	if err := alerter.TestAlert(); err != nil {
+	alerter, err := alert.NewManager(cfg)
+	if err != nil {
+		fmt.Printf("Error creating alert manager: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Webhook templates get a sample of the first node's status
+	alerter.SetTestStatus(monitor.SampleStatus(cfg.Node[0]))
+
+	if testAlertChannel == "" || testAlertChannel == "all" {
+		fmt.Println("Sending test alert...")
+		err = alerter.TestAlert()
+	} else {
+		fmt.Printf("Sending test alert to %s...\n", testAlertChannel)
+		err = alerter.TestAlertTo(testAlertChannel)
+	}
+	if err != nil {
 		fmt.Printf("Error sending test alert: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for problems",
+	Long:  `Load the configuration file and report every problem found, exiting non-zero if there are any.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runValidate()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+// runValidate loads and validates the configuration
+func runValidate() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !printConfigProblems(cfg) {
+		os.Exit(1)
+	}
+
+	configFile, _ := config.ConfigFile()
+	fmt.Printf("✅ Configuration %s is valid\n", configFile)
+}
+
+// printConfigProblems prints every validation problem in the configuration
+// and reports whether it is valid
+func printConfigProblems(cfg *config.Config) bool {
+	errs := config.Validate(cfg)
+	if len(errs) == 0 {
+		return true
+	}
+
+	fmt.Printf("❌ Found %d configuration problem(s):\n", len(errs))
+	for _, err := range errs {
+		fmt.Printf("   - %v\n", err)
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/21state/celestia-watchtower/version"
+	"github.com/spf13/cobra"
+)
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Long:  `Print the version, git commit and build date of this build.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("Version:    %s\n", version.Version)
+		fmt.Printf("Commit:     %s\n", version.Commit)
+		fmt.Printf("Build Date: %s\n", version.Date)
+		fmt.Printf("Go Version: %s\n", runtime.Version())
+	},
+}
+
+func init() {
+	// Support --version on the root command too
+	rootCmd.Version = version.String()
+	rootCmd.SetVersionTemplate("celestia-watchtower {{.Version}}\n")
+
+	rootCmd.AddCommand(versionCmd)
+}
@@ -4,90 +4,538 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Node struct {
-		RPCEndpoint string `yaml:"rpc_endpoint"`
-		AuthToken   string `yaml:"auth_token"`
-	} `yaml:"node"`
+	Node Nodes `yaml:"node"`
 
 	Monitoring struct {
 		CheckInterval int `yaml:"check_interval"` // in seconds
+		MaxHistory    int `yaml:"max_history"`    // checks kept in the history file, which grows a tenth past it between trims, 0 keeps all
+		JitterSeconds int `yaml:"jitter_seconds"` // random delay of up to this before each check, 0 disables it
+
+		// Where the latest status is written for the status and healthcheck
+		// commands, e.g. for a service running with a different home directory
+		StatusFile     string `yaml:"status_file"`      // empty for ~/.celestia-watchtower/status.json, the history, alert state and outbox files are kept next to it
+		StatusFileMode string `yaml:"status_file_mode"` // octal permissions, e.g. "0600" as it shows the endpoints
+
+		// Every check is also written to an InfluxDB v2 bucket if enabled
+		InfluxDB struct {
+			Enabled        bool   `yaml:"enabled"`
+			URL            string `yaml:"url"` // e.g. http://localhost:8086
+			Org            string `yaml:"org"`
+			Bucket         string `yaml:"bucket"`
+			Token          string `yaml:"token"`
+			BatchSize      int    `yaml:"batch_size"`      // points buffered before a write
+			TimeoutSeconds int    `yaml:"timeout_seconds"` // keeps a slow InfluxDB from delaying checks
+		} `yaml:"influxdb"`
 	} `yaml:"monitoring"`
 
+	Heartbeat struct {
+		URL            string `yaml:"url"`             // pinged after every successful check, e.g. a healthchecks.io ping URL
+		FailureURL     string `yaml:"failure_url"`     // pinged when a check fails
+		TimeoutSeconds int    `yaml:"timeout_seconds"` // keeps a slow heartbeat service from delaying checks
+	} `yaml:"heartbeat"`
+
+	Logging struct {
+		Format     string `yaml:"format"`      // "text" or "json"
+		File       string `yaml:"file"`        // also write logs to this file, empty logs to stdout only
+		MaxSizeMB  int    `yaml:"max_size_mb"` // rotate the log file once it reaches this size
+		MaxBackups int    `yaml:"max_backups"` // rotated log files to keep, 0 keeps all
+
+		// Time zone and Go layout of the timestamps in status output and alerts
+		Timezone        string `yaml:"timezone"`         // IANA name, e.g. "Asia/Singapore", empty for local time
+		TimestampFormat string `yaml:"timestamp_format"` // e.g. "2006-01-02 15:04:05 MST"
+	} `yaml:"logging"`
+
 	Alerts struct {
-		Enabled bool `yaml:"enabled"`
+		Enabled              bool `yaml:"enabled"`
+		AlertCooldownMinutes int  `yaml:"alert_cooldown_minutes"` // suppress repeated alerts of the same type
+		NotifyRecovery       bool `yaml:"notify_recovery"`        // notify when an unhealthy node is healthy again
+		MaxRetries           int  `yaml:"max_retries"`            // retries of a delivery failing with a server or network error
+		HTTPTimeoutSeconds   int  `yaml:"http_timeout_seconds"`   // keeps a hung channel endpoint from blocking checks
+		SendTimeoutSeconds   int  `yaml:"send_timeout_seconds"`   // no delivery retries are started after this long, a running attempt is bounded by http_timeout_seconds
+
+		// A node whose checks fail this many times in a row is reported
+		// unreachable, and failed queries of checks that are partial this many
+		// times in a row are reported
+		UnreachableAfterChecks int `yaml:"unreachable_after_checks"`
+
+		// While a node stays unhealthy after its alert, send a reminder when
+		// nothing went out for this long. 0 disables reminders.
+		ReminderIntervalMinutes int `yaml:"reminder_interval_minutes"`
+
+		// Alerts for a node unhealthy this long also go to the escalation channels
+		EscalateAfterMinutes int      `yaml:"escalate_after_minutes"` // 0 disables escalation
+		EscalateAfterChecks  int      `yaml:"escalate_after_checks"`  // consecutive unhealthy checks, 0 disables
+		EscalationChannels   []string `yaml:"escalation_channels"`    // e.g. ["twilio", "pagerduty"]
+
+		// A periodic summary of every node, sent only to its own channels
+		Digest struct {
+			Enabled       bool     `yaml:"enabled"`
+			IntervalHours int      `yaml:"interval_hours"` // time between digests, e.g. 1 for hourly or 24 for daily
+			Channels      []string `yaml:"channels"`       // e.g. ["discord"]
+			Exclusive     bool     `yaml:"exclusive"`      // keep incident alerts out of the digest channels
+		} `yaml:"digest"`
+
+		// Deliveries that fail are kept in an outbox and retried
+		Outbox struct {
+			Enabled      bool `yaml:"enabled"`
+			MaxEntries   int  `yaml:"max_entries"`   // oldest entries are dropped beyond this
+			MaxAgeHours  int  `yaml:"max_age_hours"` // entries older than this are dropped
+			RetrySeconds int  `yaml:"retry_seconds"` // time between delivery attempts
+		} `yaml:"outbox"`
+
+		// Every alert and its delivery results are recorded in alerts.jsonl
+		History struct {
+			Enabled   bool `yaml:"enabled"`
+			MaxSizeMB int  `yaml:"max_size_mb"` // rotate the file once it reaches this size
+		} `yaml:"history"`
+
+		// Alerts are not delivered during maintenance windows or while
+		// silenced with 'celestia-watchtower silence'
+		MaintenanceWindows []MaintenanceWindow `yaml:"maintenance_windows"`
 
 		Telegram struct {
-			Enabled  bool   `yaml:"enabled"`
-			BotToken string `yaml:"bot_token"`
-			ChatID   string `yaml:"chat_id"`
+			Enabled     bool       `yaml:"enabled"`
+			MinSeverity string     `yaml:"min_severity"` // lowest severity sent to this channel: "info", "warning" or "critical"
+			BotToken    string     `yaml:"bot_token"`
+			ChatIDs     StringList `yaml:"chat_id"`    // one chat ID or a list
+			ThreadID    int        `yaml:"thread_id"`  // forum topic to post in, 0 for the main chat
+			ParseMode   string     `yaml:"parse_mode"` // "markdown" (MarkdownV2), "html" or "none"
+			APIURL      string     `yaml:"api_url"`    // Bot API base URL, empty for https://api.telegram.org
+
+			// Add an Acknowledge button to alerts and poll the bot for taps on it
+			// and for the /ack and /status commands. The bot must not have a
+			// webhook set.
+			Interactive bool `yaml:"interactive"`
 		} `yaml:"telegram"`
 
 		Discord struct {
-			Enabled bool   `yaml:"enabled"`
-			Webhook string `yaml:"webhook"`
+			Enabled      bool     `yaml:"enabled"`
+			MinSeverity  string   `yaml:"min_severity"`
+			Webhook      string   `yaml:"webhook"`
+			MentionRoles []string `yaml:"mention_roles"` // role IDs pinged on unhealthy alerts
+			MentionUsers []string `yaml:"mention_users"` // user IDs pinged on unhealthy alerts
+			Embeds       bool     `yaml:"embeds"`        // send rich embeds, false sends plain content
 		} `yaml:"discord"`
 
 		Twilio struct {
-			Enabled     bool   `yaml:"enabled"`
-			AccountSID  string `yaml:"account_sid"`
-			AuthToken   string `yaml:"auth_token"`
-			FromNumber  string `yaml:"from_number"`
-			ToNumber    string `yaml:"to_number"`
+			Enabled     bool       `yaml:"enabled"`
+			MinSeverity string     `yaml:"min_severity"`
+			AccountSID  string     `yaml:"account_sid"`
+			AuthToken   string     `yaml:"auth_token"`
+			FromNumber  string     `yaml:"from_number"`
+			ToNumbers   StringList `yaml:"to_number"` // one number or a list
+			Channel     string     `yaml:"channel"`   // "sms", "whatsapp" or "both"
+			APIURL      string     `yaml:"api_url"`   // REST API base URL, empty for https://api.twilio.com
 		} `yaml:"twilio"`
+
+		Slack struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			WebhookURL  string `yaml:"webhook_url"`
+		} `yaml:"slack"`
+
+		Webhook struct {
+			Enabled      bool              `yaml:"enabled"`
+			MinSeverity  string            `yaml:"min_severity"`
+			URL          string            `yaml:"url"`
+			Method       string            `yaml:"method"`
+			ContentType  string            `yaml:"content_type"`
+			Headers      map[string]string `yaml:"headers"`
+			BodyTemplate string            `yaml:"body_template"` // Go text/template rendered to the request body, .Status is nil for digests and unreachable nodes so guard it with {{with .Status}}
+			Secret       string            `yaml:"secret"`        // signs each request with HMAC-SHA256, empty sends unsigned requests
+		} `yaml:"webhook"`
+
+		Pushover struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			UserKey     string `yaml:"user_key"`
+			AppToken    string `yaml:"app_token"`
+			Priority    int    `yaml:"priority"` // priority for unhealthy alerts, recoveries always use 0
+			Sound       string `yaml:"sound"`
+		} `yaml:"pushover"`
+
+		Teams struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			WebhookURL  string `yaml:"webhook_url"`
+		} `yaml:"teams"`
+
+		SNS struct {
+			Enabled         bool   `yaml:"enabled"`
+			MinSeverity     string `yaml:"min_severity"`
+			Region          string `yaml:"region"`
+			TopicARN        string `yaml:"topic_arn"`     // publish to this topic, or
+			PhoneNumber     string `yaml:"phone_number"`  // send an SMS directly to this E.164 number
+			AccessKeyID     string `yaml:"access_key_id"` // leave empty to use the default AWS credential chain
+			SecretAccessKey string `yaml:"secret_access_key"`
+		} `yaml:"sns"`
+
+		PagerDuty struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			RoutingKey  string `yaml:"routing_key"` // Events API v2 integration key
+		} `yaml:"pagerduty"`
+
+		Webex struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			BotToken    string `yaml:"bot_token"`
+			RoomID      string `yaml:"room_id"`
+		} `yaml:"webex"`
+
+		XMPP struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			JID         string `yaml:"jid"` // account the alerts are sent from, e.g. watchtower@example.com
+			Password    string `yaml:"password"`
+			Server      string `yaml:"server"`    // host:port, empty uses the JID domain on port 5222
+			Recipient   string `yaml:"recipient"` // JID that receives the alerts
+		} `yaml:"xmpp"`
+
+		MQTT struct {
+			Enabled       bool   `yaml:"enabled"`
+			MinSeverity   string `yaml:"min_severity"`
+			BrokerURL     string `yaml:"broker_url"` // e.g. tcp://localhost:1883 or ssl://broker:8883
+			Username      string `yaml:"username"`
+			Password      string `yaml:"password"`
+			ClientID      string `yaml:"client_id"`
+			TopicPrefix   string `yaml:"topic_prefix"` // alerts go to <prefix>/alert, statuses to <prefix>/status
+			QoS           byte   `yaml:"qos"`          // 0, 1 or 2
+			Retain        bool   `yaml:"retain"`
+			PublishStatus bool   `yaml:"publish_status"` // publish the status of every check
+		} `yaml:"mqtt"`
+
+		Kafka struct {
+			Enabled       bool     `yaml:"enabled"`
+			MinSeverity   string   `yaml:"min_severity"`
+			Brokers       []string `yaml:"brokers"` // e.g. ["kafka-1:9092", "kafka-2:9092"]
+			Topic         string   `yaml:"topic"`
+			SASLMechanism string   `yaml:"sasl_mechanism"` // "plain", "scram-sha-256", "scram-sha-512" or empty for none
+			Username      string   `yaml:"username"`
+			Password      string   `yaml:"password"`
+			TLS           bool     `yaml:"tls"`
+		} `yaml:"kafka"`
+
+		NATS struct {
+			Enabled         bool   `yaml:"enabled"`
+			MinSeverity     string `yaml:"min_severity"`
+			ServerURL       string `yaml:"server_url"`       // e.g. nats://localhost:4222
+			CredentialsFile string `yaml:"credentials_file"` // optional .creds file for authentication
+			Subject         string `yaml:"subject"`
+			PublishStatus   bool   `yaml:"publish_status"` // also publish the status JSON to <subject>.status
+		} `yaml:"nats"`
+
+		Opsgenie struct {
+			Enabled     bool   `yaml:"enabled"`
+			MinSeverity string `yaml:"min_severity"`
+			APIKey      string `yaml:"api_key"` // API integration key
+			Region      string `yaml:"region"`  // "us" or "eu"
+		} `yaml:"opsgenie"`
 	} `yaml:"alerts"`
 
 	Thresholds struct {
 		SyncStatus struct {
+			BlocksBehindWarning  int `yaml:"blocks_behind_warning"` // blocks behind before a warning, 0 disables the warning band
 			BlocksBehindCritical int `yaml:"blocks_behind_critical"`
+			StallTimeoutSeconds  int `yaml:"stall_timeout_seconds"` // max time the local head may stay still while behind, 0 disables it
+			MaxHeadAgeSeconds    int `yaml:"max_head_age_seconds"`  // max age of the newest network header, 0 disables the check
 		} `yaml:"sync_status"`
 
 		Network struct {
+			MinPeersWarning int `yaml:"min_peers_warning"` // peers below which a warning is raised, 0 disables the warning band
 			MinPeersHealthy int `yaml:"min_peers_healthy"`
+
+			// The peer count is averaged over this many checks before it is
+			// compared to the minimums, 0 or 1 compares every reading
+			PeerSmoothingChecks int `yaml:"peer_smoothing_checks"`
+			PeerHysteresis      int `yaml:"peer_hysteresis"` // peers above the minimum a low peer count must reach to recover
+
+			// A node nobody can dial may be behind a NAT or firewall, light
+			// nodes that are never dialed may want to turn this off
+			RequireInbound bool `yaml:"require_inbound"`
+
+			// Alert when the NAT status changes: "off", "any" or "from_public"
+			// to only alert when it is no longer Public
+			NATChangeAlert string `yaml:"nat_change_alert"`
 		} `yaml:"network"`
+
+		// A node moving no data is effectively dead even if it reports peers
+		Bandwidth struct {
+			MinRateInBytes  int `yaml:"min_rate_in_bytes"`  // min inbound bytes per second, 0 disables the check
+			MinRateOutBytes int `yaml:"min_rate_out_bytes"` // min outbound bytes per second, 0 disables the check
+			LowChecks       int `yaml:"low_checks"`         // consecutive low checks before alerting, avoids flapping
+		} `yaml:"bandwidth"`
+
+		Sampling struct {
+			MaxBehind             int `yaml:"max_behind"`              // max headers the DASer may lag the network head, 0 disables the check
+			HeadersBehindCritical int `yaml:"headers_behind_critical"` // lag that makes the alert critical, 0 uses twice max_behind
+		} `yaml:"sampling"`
+
+		Disk struct {
+			MinFreePercent float64 `yaml:"min_free_percent"`
+			MaxStoreSizeGB int     `yaml:"max_store_size_gb"` // max size of a node's store_path, 0 disables the check
+		} `yaml:"disk"`
+
+		Resources struct {
+			MaxMemoryMB int `yaml:"max_memory_mb"` // max memory reserved by the node's libp2p stack, 0 disables the check
+		} `yaml:"resources"`
+
+		// Limits for the node process set with process_unit or process_pid_file
+		Process struct {
+			MaxCPUPercent float64 `yaml:"max_cpu_percent"` // max CPU use since the previous check, 100 is one full core, 0 disables the check
+			MaxMemoryMB   int     `yaml:"max_memory_mb"`   // max resident memory, 0 disables the check
+		} `yaml:"process"`
+
+		RPC struct {
+			MaxLatencyMs int `yaml:"max_latency_ms"` // max average duration of a check, 0 disables the check
+		} `yaml:"rpc"`
+
+		// Blob submission costs gas, so the check is opt-in and runs on its own interval
+		Blob struct {
+			Enabled          bool   `yaml:"enabled"`
+			Namespace        string `yaml:"namespace"`          // namespace ID of the test blobs, up to 10 bytes
+			MaxSubmitSeconds int    `yaml:"max_submit_seconds"` // max time from submission to inclusion
+			IntervalMinutes  int    `yaml:"interval_minutes"`   // time between test submissions
+		} `yaml:"blob"`
 	} `yaml:"thresholds"`
 }
 
+// DefaultWebhookTemplate is the default body template for the webhook channel
+const DefaultWebhookTemplate = `{"message": {{json .Message}}, "timestamp": {{json .Timestamp}}}`
+
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	cfg := &Config{}
 
 	// Node defaults
-	cfg.Node.RPCEndpoint = "http://localhost:26658"
-	cfg.Node.AuthToken = ""
+	node := DefaultNodeConfig()
+	node.Name = "default"
+	cfg.Node = Nodes{node}
 
 	// Monitoring defaults
 	cfg.Monitoring.CheckInterval = 60 // 1 minute
+	cfg.Monitoring.MaxHistory = 10000
+	cfg.Monitoring.JitterSeconds = 0
+	cfg.Monitoring.StatusFile = ""
+	cfg.Monitoring.StatusFileMode = DefaultStatusFileMode
+	cfg.Monitoring.InfluxDB.Enabled = false
+	cfg.Monitoring.InfluxDB.URL = "http://localhost:8086"
+	cfg.Monitoring.InfluxDB.Org = ""
+	cfg.Monitoring.InfluxDB.Bucket = "celestia"
+	cfg.Monitoring.InfluxDB.Token = ""
+	cfg.Monitoring.InfluxDB.BatchSize = 10
+	cfg.Monitoring.InfluxDB.TimeoutSeconds = 5
+
+	// Heartbeat defaults
+	cfg.Heartbeat.URL = ""
+	cfg.Heartbeat.FailureURL = ""
+	cfg.Heartbeat.TimeoutSeconds = 5
+
+	// Logging defaults
+	cfg.Logging.Format = "text"
+	cfg.Logging.File = ""
+	cfg.Logging.MaxSizeMB = 100
+	cfg.Logging.MaxBackups = 3
+	cfg.Logging.Timezone = ""
+	cfg.Logging.TimestampFormat = DefaultTimestampFormat
 
 	// Alerts defaults
 	cfg.Alerts.Enabled = false
+	cfg.Alerts.AlertCooldownMinutes = 15
+	cfg.Alerts.NotifyRecovery = true
+	cfg.Alerts.MaxRetries = 2
+	cfg.Alerts.HTTPTimeoutSeconds = 10
+	cfg.Alerts.SendTimeoutSeconds = 30
+	cfg.Alerts.UnreachableAfterChecks = 2
+	cfg.Alerts.ReminderIntervalMinutes = 0
+	cfg.Alerts.EscalateAfterMinutes = 0
+	cfg.Alerts.EscalateAfterChecks = 0
+	cfg.Alerts.EscalationChannels = []string{}
+	cfg.Alerts.Digest.Enabled = false
+	cfg.Alerts.Digest.IntervalHours = 24
+	cfg.Alerts.Digest.Channels = []string{}
+	cfg.Alerts.Digest.Exclusive = false
+	cfg.Alerts.Outbox.Enabled = true
+	cfg.Alerts.Outbox.MaxEntries = 500
+	cfg.Alerts.Outbox.MaxAgeHours = 24
+	cfg.Alerts.Outbox.RetrySeconds = 60
+	cfg.Alerts.History.Enabled = true
+	cfg.Alerts.History.MaxSizeMB = 10
+	cfg.Alerts.MaintenanceWindows = []MaintenanceWindow{}
 	cfg.Alerts.Telegram.Enabled = false
+	cfg.Alerts.Telegram.MinSeverity = "info"
 	cfg.Alerts.Telegram.BotToken = ""
-	cfg.Alerts.Telegram.ChatID = ""
+	cfg.Alerts.Telegram.ChatIDs = StringList{}
+	cfg.Alerts.Telegram.ThreadID = 0
+	cfg.Alerts.Telegram.ParseMode = "markdown"
+	cfg.Alerts.Telegram.APIURL = ""
+	cfg.Alerts.Telegram.Interactive = false
 	
 	// Discord alerts
 	cfg.Alerts.Discord.Enabled = false
+	cfg.Alerts.Discord.MinSeverity = "info"
 	cfg.Alerts.Discord.Webhook = ""
+	cfg.Alerts.Discord.MentionRoles = []string{}
+	cfg.Alerts.Discord.MentionUsers = []string{}
+	cfg.Alerts.Discord.Embeds = true
 	
 	// Twilio alerts
 	cfg.Alerts.Twilio.Enabled = false
+	cfg.Alerts.Twilio.MinSeverity = "info"
 	cfg.Alerts.Twilio.AccountSID = ""
 	cfg.Alerts.Twilio.AuthToken = ""
 	cfg.Alerts.Twilio.FromNumber = ""
-	cfg.Alerts.Twilio.ToNumber = ""
+	cfg.Alerts.Twilio.ToNumbers = StringList{}
+	cfg.Alerts.Twilio.Channel = "sms"
+	cfg.Alerts.Twilio.APIURL = ""
+
+	// Slack alerts
+	cfg.Alerts.Slack.Enabled = false
+	cfg.Alerts.Slack.MinSeverity = "info"
+	cfg.Alerts.Slack.WebhookURL = ""
+
+	// Webhook alerts
+	cfg.Alerts.Webhook.Enabled = false
+	cfg.Alerts.Webhook.MinSeverity = "info"
+	cfg.Alerts.Webhook.URL = ""
+	cfg.Alerts.Webhook.Method = "POST"
+	cfg.Alerts.Webhook.ContentType = "application/json"
+	cfg.Alerts.Webhook.Headers = map[string]string{}
+	cfg.Alerts.Webhook.BodyTemplate = DefaultWebhookTemplate
+
+	// Pushover alerts
+	cfg.Alerts.Pushover.Enabled = false
+	cfg.Alerts.Pushover.MinSeverity = "info"
+	cfg.Alerts.Pushover.UserKey = ""
+	cfg.Alerts.Pushover.AppToken = ""
+	cfg.Alerts.Pushover.Priority = 1
+	cfg.Alerts.Pushover.Sound = ""
+
+	// Microsoft Teams alerts
+	cfg.Alerts.Teams.Enabled = false
+	cfg.Alerts.Teams.MinSeverity = "info"
+	cfg.Alerts.Teams.WebhookURL = ""
+
+	// AWS SNS alerts
+	cfg.Alerts.SNS.Enabled = false
+	cfg.Alerts.SNS.MinSeverity = "info"
+	cfg.Alerts.SNS.Region = ""
+	cfg.Alerts.SNS.TopicARN = ""
+	cfg.Alerts.SNS.PhoneNumber = ""
+	cfg.Alerts.SNS.AccessKeyID = ""
+	cfg.Alerts.SNS.SecretAccessKey = ""
+
+	// PagerDuty alerts
+	cfg.Alerts.PagerDuty.Enabled = false
+	cfg.Alerts.PagerDuty.MinSeverity = "info"
+	cfg.Alerts.PagerDuty.RoutingKey = ""
+
+	// Webex alerts
+	cfg.Alerts.Webex.Enabled = false
+	cfg.Alerts.Webex.MinSeverity = "info"
+	cfg.Alerts.Webex.BotToken = ""
+	cfg.Alerts.Webex.RoomID = ""
+
+	// XMPP alerts
+	cfg.Alerts.XMPP.Enabled = false
+	cfg.Alerts.XMPP.MinSeverity = "info"
+	cfg.Alerts.XMPP.JID = ""
+	cfg.Alerts.XMPP.Password = ""
+	cfg.Alerts.XMPP.Server = ""
+	cfg.Alerts.XMPP.Recipient = ""
+
+	// MQTT alerts
+	cfg.Alerts.MQTT.Enabled = false
+	cfg.Alerts.MQTT.MinSeverity = "info"
+	cfg.Alerts.MQTT.BrokerURL = ""
+	cfg.Alerts.MQTT.Username = ""
+	cfg.Alerts.MQTT.Password = ""
+	cfg.Alerts.MQTT.ClientID = "celestia-watchtower"
+	cfg.Alerts.MQTT.TopicPrefix = "celestia-watchtower"
+	cfg.Alerts.MQTT.QoS = 0
+	cfg.Alerts.MQTT.Retain = false
+	cfg.Alerts.MQTT.PublishStatus = false
+
+	// Kafka alerts
+	cfg.Alerts.Kafka.Enabled = false
+	cfg.Alerts.Kafka.MinSeverity = "info"
+	cfg.Alerts.Kafka.Brokers = []string{}
+	cfg.Alerts.Kafka.Topic = ""
+	cfg.Alerts.Kafka.SASLMechanism = ""
+	cfg.Alerts.Kafka.Username = ""
+	cfg.Alerts.Kafka.Password = ""
+	cfg.Alerts.Kafka.TLS = false
+
+	// NATS alerts
+	cfg.Alerts.NATS.Enabled = false
+	cfg.Alerts.NATS.MinSeverity = "info"
+	cfg.Alerts.NATS.ServerURL = ""
+	cfg.Alerts.NATS.CredentialsFile = ""
+	cfg.Alerts.NATS.Subject = "celestia-watchtower.alerts"
+	cfg.Alerts.NATS.PublishStatus = false
+
+	// Opsgenie alerts
+	cfg.Alerts.Opsgenie.Enabled = false
+	cfg.Alerts.Opsgenie.MinSeverity = "info"
+	cfg.Alerts.Opsgenie.APIKey = ""
+	cfg.Alerts.Opsgenie.Region = "us"
 
 	// Threshold defaults
+	cfg.Thresholds.SyncStatus.BlocksBehindWarning = 0
 	cfg.Thresholds.SyncStatus.BlocksBehindCritical = 10
+	cfg.Thresholds.SyncStatus.StallTimeoutSeconds = 300
+	cfg.Thresholds.SyncStatus.MaxHeadAgeSeconds = 60
+	cfg.Thresholds.Network.MinPeersWarning = 0
 	cfg.Thresholds.Network.MinPeersHealthy = 5
+	cfg.Thresholds.Network.PeerSmoothingChecks = 0
+	cfg.Thresholds.Network.PeerHysteresis = 0
+	cfg.Thresholds.Network.RequireInbound = true
+	cfg.Thresholds.Network.NATChangeAlert = "off"
+	cfg.Thresholds.Bandwidth.MinRateInBytes = 0
+	cfg.Thresholds.Bandwidth.MinRateOutBytes = 0
+	cfg.Thresholds.Bandwidth.LowChecks = 3
+	cfg.Thresholds.Sampling.MaxBehind = 0
+	cfg.Thresholds.Sampling.HeadersBehindCritical = 0
+	cfg.Thresholds.Disk.MinFreePercent = 10
+	cfg.Thresholds.Disk.MaxStoreSizeGB = 0
+	cfg.Thresholds.Resources.MaxMemoryMB = 0
+	cfg.Thresholds.Process.MaxCPUPercent = 0
+	cfg.Thresholds.Process.MaxMemoryMB = 0
+	cfg.Thresholds.RPC.MaxLatencyMs = 0
+	cfg.Thresholds.Blob.Enabled = false
+	cfg.Thresholds.Blob.Namespace = "watchtower"
+	cfg.Thresholds.Blob.MaxSubmitSeconds = 60
+	cfg.Thresholds.Blob.IntervalMinutes = 60
 
 	return cfg
 }
 
+// AnyAlertChannelEnabled reports whether at least one alert channel is enabled
+func (c *Config) AnyAlertChannelEnabled() bool {
+	return c.Alerts.Telegram.Enabled ||
+		c.Alerts.Discord.Enabled ||
+		c.Alerts.Twilio.Enabled ||
+		c.Alerts.Slack.Enabled ||
+		c.Alerts.Webhook.Enabled ||
+		c.Alerts.Pushover.Enabled ||
+		c.Alerts.Teams.Enabled ||
+		c.Alerts.SNS.Enabled ||
+		c.Alerts.PagerDuty.Enabled ||
+		c.Alerts.Webex.Enabled ||
+		c.Alerts.XMPP.Enabled ||
+		c.Alerts.MQTT.Enabled ||
+		c.Alerts.Kafka.Enabled ||
+		c.Alerts.NATS.Enabled ||
+		c.Alerts.Opsgenie.Enabled
+}
+
 // ConfigDir returns the path to the configuration directory
 func ConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -108,6 +556,98 @@ func ConfigFile() (string, error) {
 	return filepath.Join(configDir, "config.yaml"), nil
 }
 
+// StatusFile returns the path to the status file written by the engine
+func StatusFile() (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "status.json"), nil
+}
+
+// DefaultStatusFileMode is the permissions of the status file unless
+// monitoring.status_file_mode is set
+const DefaultStatusFileMode = "0644"
+
+// StatusFilePath returns the configured path of the status file, or
+// StatusFile without one
+func (c *Config) StatusFilePath() (string, error) {
+	if c.Monitoring.StatusFile != "" {
+		return c.Monitoring.StatusFile, nil
+	}
+	return StatusFile()
+}
+
+// StatusFilePerm returns the permissions of the status file, the default
+// ones if monitoring.status_file_mode is not valid
+func (c *Config) StatusFilePerm() os.FileMode {
+	mode, err := parseFileMode(c.Monitoring.StatusFileMode)
+	if err != nil {
+		mode, _ = parseFileMode(DefaultStatusFileMode)
+	}
+	return mode
+}
+
+// parseFileMode parses octal permissions such as "0600". Empty means the
+// default status file mode.
+func parseFileMode(s string) (os.FileMode, error) {
+	if s == "" {
+		s = DefaultStatusFileMode
+	}
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("%q is not octal file permissions such as \"0600\"", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// stateFilePath returns the path to a state file of the watchtower, kept
+// next to the status file
+func (c *Config) stateFilePath(name string) (string, error) {
+	statusFile, err := c.StatusFilePath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(statusFile), name), nil
+}
+
+// HistoryFilePath returns the path to the status history file written by the engine
+func (c *Config) HistoryFilePath() (string, error) {
+	return c.stateFilePath("history.jsonl")
+}
+
+// AlertStateFilePath returns the path to the alert cooldown state kept across restarts
+func (c *Config) AlertStateFilePath() (string, error) {
+	return c.stateFilePath("alert_state.json")
+}
+
+// OutboxFilePath returns the path to the queue of alerts waiting to be delivered
+func (c *Config) OutboxFilePath() (string, error) {
+	return c.stateFilePath("outbox.jsonl")
+}
+
+// AlertHistoryFile returns the path to the record of sent alerts
+func AlertHistoryFile() (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "alerts.jsonl"), nil
+}
+
+// SilenceFile returns the path to the ad-hoc silence written by the silence command
+func SilenceFile() (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "silence.json"), nil
+}
+
 // SaveConfig saves the configuration to the config file
 func SaveConfig(cfg *Config) error {
 	configFile, err := ConfigFile()
@@ -157,11 +697,28 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse config
-	cfg := &Config{}
+	// Parse config on top of the defaults so new fields get sane values
+	cfg := DefaultConfig()
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if len(cfg.Node) == 0 {
+		return nil, fmt.Errorf("no nodes configured")
+	}
+
+	if err := cfg.Node.assignNames(); err != nil {
+		return nil, fmt.Errorf("invalid node configuration: %w", err)
+	}
+	cfg.Node.assignLabels()
+	if err := cfg.Node.expandPaths(); err != nil {
+		return nil, fmt.Errorf("invalid node configuration: %w", err)
+	}
+
+	// Fill in secrets referenced as ${ENV_VAR}
+	if err := expandSecrets(cfg); err != nil {
+		return nil, fmt.Errorf("failed to expand config secrets: %w", err)
+	}
+
 	return cfg, nil
 }
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStateFilesFollowStatusFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name       string
+		statusFile string
+		wantDir    string
+	}{
+		{"default", "", filepath.Join(home, ".celestia-watchtower")},
+		{"status_file set", "/var/lib/celestia-watchtower/status.json", "/var/lib/celestia-watchtower"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Monitoring.StatusFile = tt.statusFile
+
+			files := map[string]func() (string, error){
+				"history.jsonl":    cfg.HistoryFilePath,
+				"alert_state.json": cfg.AlertStateFilePath,
+				"outbox.jsonl":     cfg.OutboxFilePath,
+			}
+			for name, path := range files {
+				got, err := path()
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if want := filepath.Join(tt.wantDir, name); got != want {
+					t.Errorf("path = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow is a period during which alerts are not delivered.
+// Start and end are either RFC 3339 timestamps for a one-off window or
+// HH:MM times for a window recurring on the given days, such as nightly
+// quiet hours.
+type MaintenanceWindow struct {
+	Name     string   `yaml:"name"`
+	Start    string   `yaml:"start"`              // e.g. "2025-06-01T02:00:00Z" or "02:00"
+	End      string   `yaml:"end"`                // e.g. "2025-06-01T04:00:00Z" or "04:00", may be past midnight
+	Days     []string `yaml:"days,omitempty"`     // weekdays a recurring window starts on, e.g. ["sat", "sun"], empty means every day
+	Timezone string   `yaml:"timezone,omitempty"` // IANA zone of recurring HH:MM times, e.g. "Asia/Singapore", empty for local time
+}
+
+// clockLayout is the layout of recurring window times
+const clockLayout = "15:04"
+
+// weekdays maps the accepted day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Validate checks that the window times and days can be parsed
+func (w MaintenanceWindow) Validate() error {
+	if start, end, err := w.oneOff(); err == nil {
+		if !end.After(start) {
+			return fmt.Errorf("end must be after start")
+		}
+		if len(w.Days) > 0 || w.Timezone != "" {
+			return fmt.Errorf("days and timezone only apply to recurring HH:MM windows")
+		}
+		return nil
+	}
+
+	if w.Timezone != "" {
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return fmt.Errorf("timezone %q is not an IANA time zone name such as \"UTC\" or \"Asia/Singapore\"", w.Timezone)
+		}
+	}
+
+	if _, err := time.Parse(clockLayout, w.Start); err != nil {
+		return fmt.Errorf("start %q must be an RFC 3339 time or HH:MM", w.Start)
+	}
+	if _, err := time.Parse(clockLayout, w.End); err != nil {
+		return fmt.Errorf("end %q must be an RFC 3339 time or HH:MM", w.End)
+	}
+	for _, day := range w.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("unknown day %q, use sun, mon, tue, wed, thu, fri or sat", day)
+		}
+	}
+
+	return nil
+}
+
+// Active reports whether the window covers the given time. The end of a
+// window is exclusive.
+func (w MaintenanceWindow) Active(now time.Time) bool {
+	if start, end, err := w.oneOff(); err == nil {
+		return !now.Before(start) && now.Before(end)
+	}
+
+	startClock, err := time.Parse(clockLayout, w.Start)
+	if err != nil {
+		return false
+	}
+	endClock, err := time.Parse(clockLayout, w.End)
+	if err != nil {
+		return false
+	}
+
+	loc := time.Local
+	if w.Timezone != "" {
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return false
+		}
+	}
+
+	// Check the occurrences starting today and yesterday, since a window
+	// may run past midnight
+	local := now.In(loc)
+	for _, offset := range []int{0, -1} {
+		day := local.AddDate(0, 0, offset)
+		if !w.onDay(day.Weekday()) {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), startClock.Hour(), startClock.Minute(), 0, 0, loc)
+		end := time.Date(day.Year(), day.Month(), day.Day(), endClock.Hour(), endClock.Minute(), 0, 0, loc)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !local.Before(start) && local.Before(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oneOff parses the window as a pair of RFC 3339 timestamps
+func (w MaintenanceWindow) oneOff() (time.Time, time.Time, error) {
+	start, err := time.Parse(time.RFC3339, w.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := time.Parse(time.RFC3339, w.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// onDay reports whether a recurring window starts on the given weekday
+func (w MaintenanceWindow) onDay(weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if weekdays[strings.ToLower(day)] == weekday {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NodeConfig holds the connection settings for a single monitored node
+type NodeConfig struct {
+	Name        string `yaml:"name,omitempty"`
+	Label       string `yaml:"label,omitempty"` // tells watchtowers apart in alerts, defaults to the hostname
+	RPCEndpoint string `yaml:"rpc_endpoint"`
+	AuthToken   string `yaml:"auth_token"`         // may reference an environment variable as ${ENV_VAR}
+	DataDir     string `yaml:"data_dir,omitempty"` // node data directory to watch for free space, empty skips the check, may start with ~/
+	Type        string `yaml:"type,omitempty"`     // "light", "full" or "bridge", "auto" detects it with node.Info
+
+	// Node store directory whose size is tracked, e.g. ~/.celestia-light-mocha-4
+	// where ~/ is the home directory, empty skips it. Walking a large store takes a while, so it is measured
+	// every store_interval_minutes rather than on every check.
+	StorePath            string `yaml:"store_path,omitempty"`
+	StoreIntervalMinutes int    `yaml:"store_interval_minutes,omitempty"`
+
+	// Node process whose CPU and memory use is tracked, found by its
+	// systemd unit or its pid file, both empty skip it. Linux only.
+	ProcessUnit    string `yaml:"process_unit,omitempty"` // e.g. "celestia-light"
+	ProcessPIDFile string `yaml:"process_pid_file,omitempty"`
+
+	RPCRetries      int `yaml:"rpc_retries"`        // retries per RPC call after the first failure
+	RPCRetryDelayMs int `yaml:"rpc_retry_delay_ms"` // initial backoff, doubled each retry
+
+	RPCTimeoutSeconds int `yaml:"rpc_timeout_seconds"` // per-call timeout, 0 disables it
+
+	// Other endpoints of the same node, with the same auth token and TLS
+	// settings, used in order while rpc_endpoint cannot be reached
+	FallbackEndpoints   []string `yaml:"fallback_endpoints,omitempty"`
+	PrimaryRetryMinutes int      `yaml:"primary_retry_minutes,omitempty"` // time on a fallback before rpc_endpoint is tried again, 0 stays on it
+
+	// Guards against watching a node on the wrong network or a reset node
+	ExpectedChainID   string `yaml:"expected_chain_id,omitempty"`   // e.g. "celestia" or "mocha-4", empty skips the check
+	ExpectedMinHeight uint64 `yaml:"expected_min_height,omitempty"` // local heights below this are unhealthy, 0 skips the check
+
+	// Trusted "height:hash" headers of the canonical chain. A node with a
+	// different hash at one of these heights is on a fork or corrupted.
+	Checkpoints               []string `yaml:"checkpoints,omitempty"`
+	CheckpointIntervalMinutes int      `yaml:"checkpoint_interval_minutes,omitempty"` // time between verifications, the first runs on startup
+
+	// TLS settings for https:// endpoints behind a private CA or requiring mutual TLS
+	TLS struct {
+		CACertPath         string `yaml:"ca_cert_path,omitempty"`         // PEM file with the trusted CA certificates
+		ClientCertPath     string `yaml:"client_cert_path,omitempty"`     // PEM client certificate for mutual TLS
+		ClientKeyPath      string `yaml:"client_key_path,omitempty"`      // PEM private key of the client certificate
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"` // do not verify the node certificate
+	} `yaml:"tls,omitempty"`
+}
+
+// Nodes is the list of monitored nodes. In YAML it accepts either a single
+// node mapping (the original config format) or a sequence of nodes.
+type Nodes []NodeConfig
+
+// DefaultNodeConfig returns the default settings for a node
+func DefaultNodeConfig() NodeConfig {
+	return NodeConfig{
+		RPCEndpoint:     "http://localhost:26658",
+		AuthToken:       "",
+		Type:            "auto",
+		RPCRetries:      3,
+		RPCRetryDelayMs: 500,
+
+		RPCTimeoutSeconds: 10,
+
+		PrimaryRetryMinutes: 5,
+
+		CheckpointIntervalMinutes: 60,
+
+		StoreIntervalMinutes: 10,
+	}
+}
+
+// Checkpoint is a trusted header of the canonical chain
+type Checkpoint struct {
+	Height uint64
+	Hash   string // hex
+}
+
+// ParseCheckpoint parses a checkpoint written as "height:hash"
+func ParseCheckpoint(s string) (Checkpoint, error) {
+	heightText, hash, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q must be written as height:hash", s)
+	}
+
+	height, err := strconv.ParseUint(heightText, 10, 64)
+	if err != nil || height == 0 {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q has an invalid height", s)
+	}
+	if _, err := hex.DecodeString(hash); err != nil || len(hash) != 64 {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q must have a 64 character hex hash", s)
+	}
+
+	return Checkpoint{Height: height, Hash: hash}, nil
+}
+
+// UnmarshalYAML decodes either a single node or a list of nodes
+func (n *Nodes) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.MappingNode:
+		node := DefaultNodeConfig()
+		if err := value.Decode(&node); err != nil {
+			return err
+		}
+		*n = Nodes{node}
+	case yaml.SequenceNode:
+		nodes := make(Nodes, 0, len(value.Content))
+		for _, item := range value.Content {
+			node := DefaultNodeConfig()
+			if err := item.Decode(&node); err != nil {
+				return err
+			}
+			nodes = append(nodes, node)
+		}
+		*n = nodes
+	default:
+		return fmt.Errorf("node must be a mapping or a list of mappings")
+	}
+
+	return nil
+}
+
+// MarshalYAML keeps single-node configs in the original mapping format
+func (n Nodes) MarshalYAML() (interface{}, error) {
+	if len(n) == 1 {
+		return n[0], nil
+	}
+	return []NodeConfig(n), nil
+}
+
+// DisplayName returns the node name followed by its label, e.g.
+// "default@validator-1"
+func (n NodeConfig) DisplayName() string {
+	if n.Label == "" {
+		return n.Name
+	}
+	return n.Name + "@" + n.Label
+}
+
+// assignLabels labels unlabelled nodes with the hostname of this machine
+func (n Nodes) assignLabels() {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return
+	}
+	for i := range n {
+		if n[i].Label == "" {
+			n[i].Label = hostname
+		}
+	}
+}
+
+// expandPaths replaces a leading ~/ in the node directories and pid file
+// with the home directory, as a shell would
+func (n Nodes) expandPaths() error {
+	for i := range n {
+		for _, path := range []*string{&n[i].DataDir, &n[i].StorePath, &n[i].ProcessPIDFile} {
+			expanded, err := expandHome(*path)
+			if err != nil {
+				return fmt.Errorf("node %q: %w", n[i].Name, err)
+			}
+			*path = expanded
+		}
+	}
+	return nil
+}
+
+// expandHome replaces a leading ~/ in path with the home directory
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		if path != "~" {
+			return path, nil
+		}
+		rest = ""
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s: %w", path, err)
+	}
+	return filepath.Join(homeDir, rest), nil
+}
+
+// assignNames gives unnamed nodes a default name and rejects duplicates
+func (n Nodes) assignNames() error {
+	seen := make(map[string]bool, len(n))
+	for i := range n {
+		if n[i].Name == "" {
+			if len(n) == 1 {
+				n[i].Name = "default"
+			} else {
+				n[i].Name = fmt.Sprintf("node-%d", i+1)
+			}
+		}
+
+		if seen[n[i].Name] {
+			return fmt.Errorf("duplicate node name %q", n[i].Name)
+		}
+		seen[n[i].Name] = true
+	}
+
+	return nil
+}
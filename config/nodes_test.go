@@ -0,0 +1,31 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	nodes := Nodes{{
+		Name:           "light",
+		DataDir:        "~/.celestia-light-mocha-4",
+		StorePath:      "~",
+		ProcessPIDFile: "/run/celestia.pid",
+	}}
+	if err := nodes.expandPaths(); err != nil {
+		t.Fatalf("expandPaths: %v", err)
+	}
+
+	if want := filepath.Join(home, ".celestia-light-mocha-4"); nodes[0].DataDir != want {
+		t.Errorf("DataDir = %q, want %q", nodes[0].DataDir, want)
+	}
+	if nodes[0].StorePath != home {
+		t.Errorf("StorePath = %q, want %q", nodes[0].StorePath, home)
+	}
+	if nodes[0].ProcessPIDFile != "/run/celestia.pid" {
+		t.Errorf("absolute ProcessPIDFile changed to %q", nodes[0].ProcessPIDFile)
+	}
+}
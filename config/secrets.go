@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// envReference matches a ${ENV_VAR} reference inside a config value
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandSecrets replaces ${ENV_VAR} references in the secret fields with
+// the value of the environment variable, so tokens need not be stored in
+// the config file. The supported fields are the node auth_token,
+// rpc_endpoint and fallback_endpoints, and every alert channel token, key, chat ID, phone number,
+// webhook URL, webhook header and password.
+func expandSecrets(cfg *Config) error {
+	var fields []*string
+	for i := range cfg.Node {
+		fields = append(fields, &cfg.Node[i].RPCEndpoint, &cfg.Node[i].AuthToken)
+		for j := range cfg.Node[i].FallbackEndpoints {
+			fields = append(fields, &cfg.Node[i].FallbackEndpoints[j])
+		}
+	}
+
+	fields = append(fields, &cfg.Heartbeat.URL, &cfg.Heartbeat.FailureURL, &cfg.Monitoring.InfluxDB.Token)
+
+	alerts := &cfg.Alerts
+	fields = append(fields,
+		&alerts.Telegram.BotToken,
+		&alerts.Discord.Webhook,
+		&alerts.Twilio.AccountSID,
+		&alerts.Twilio.AuthToken,
+		&alerts.Twilio.FromNumber,
+		&alerts.Slack.WebhookURL,
+		&alerts.Webhook.URL,
+		&alerts.Webhook.Secret,
+		&alerts.Pushover.UserKey,
+		&alerts.Pushover.AppToken,
+		&alerts.Teams.WebhookURL,
+		&alerts.SNS.AccessKeyID,
+		&alerts.SNS.SecretAccessKey,
+		&alerts.SNS.PhoneNumber,
+		&alerts.PagerDuty.RoutingKey,
+		&alerts.Webex.BotToken,
+		&alerts.XMPP.Password,
+		&alerts.MQTT.Password,
+		&alerts.Kafka.Password,
+		&alerts.Opsgenie.APIKey,
+	)
+	for i := range alerts.Telegram.ChatIDs {
+		fields = append(fields, &alerts.Telegram.ChatIDs[i])
+	}
+	for i := range alerts.Twilio.ToNumbers {
+		fields = append(fields, &alerts.Twilio.ToNumbers[i])
+	}
+
+	for _, field := range fields {
+		expanded, err := expandEnv(*field)
+		if err != nil {
+			return err
+		}
+		*field = expanded
+	}
+
+	for name, value := range alerts.Webhook.Headers {
+		expanded, err := expandEnv(value)
+		if err != nil {
+			return err
+		}
+		alerts.Webhook.Headers[name] = expanded
+	}
+
+	return nil
+}
+
+// expandEnv expands the ${ENV_VAR} references in value, failing if a
+// referenced variable is not set
+func expandEnv(value string) (string, error) {
+	var missing string
+	expanded := envReference.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envReference.FindStringSubmatch(ref)[1]
+		envValue, ok := os.LookupEnv(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return envValue
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("environment variable %s is not set", missing)
+	}
+
+	return expanded, nil
+}
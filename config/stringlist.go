@@ -0,0 +1,29 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// StringList is a list of strings that also accepts a single string in
+// YAML, so fields that used to take one value keep reading old configs
+type StringList []string
+
+// UnmarshalYAML decodes either a single string or a sequence of strings
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var item string
+		if err := value.Decode(&item); err != nil {
+			return err
+		}
+		*l = nil
+		if item != "" {
+			*l = StringList{item}
+		}
+		return nil
+	}
+
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
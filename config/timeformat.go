@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	// Time zone names resolve even on hosts without a zoneinfo database
+	_ "time/tzdata"
+)
+
+// DefaultTimestampFormat is the layout of timestamps in status output and
+// alert messages
+const DefaultTimestampFormat = "2006-01-02 15:04:05"
+
+// Location returns the time zone of logging.timezone, local time if it is
+// empty or not a valid zone name
+func (c *Config) Location() *time.Location {
+	if c.Logging.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Logging.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// FormatTime formats t in the configured time zone and timestamp format
+func (c *Config) FormatTime(t time.Time) string {
+	layout := c.Logging.TimestampFormat
+	if layout == "" {
+		layout = DefaultTimestampFormat
+	}
+	return t.In(c.Location()).Format(layout)
+}
+
+// validateTimezone checks that name is a time zone the host can resolve
+func validateTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("logging.timezone %q is not an IANA time zone name such as \"UTC\" or \"Asia/Singapore\"", name)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// Validate checks the configuration for semantic problems and returns
+// every problem found
+func Validate(cfg *Config) []error {
+	var errs []error
+
+	if cfg.Monitoring.CheckInterval <= 0 {
+		errs = append(errs, fmt.Errorf("monitoring.check_interval must be greater than 0"))
+	}
+
+	if cfg.Monitoring.MaxHistory < 0 {
+		errs = append(errs, fmt.Errorf("monitoring.max_history cannot be negative"))
+	}
+
+	if cfg.Monitoring.JitterSeconds < 0 || (cfg.Monitoring.CheckInterval > 0 && cfg.Monitoring.JitterSeconds >= cfg.Monitoring.CheckInterval) {
+		errs = append(errs, fmt.Errorf("monitoring.jitter_seconds must be between 0 and check_interval"))
+	}
+
+	if _, err := parseFileMode(cfg.Monitoring.StatusFileMode); err != nil {
+		errs = append(errs, fmt.Errorf("monitoring.status_file_mode: %w", err))
+	}
+
+	if influx := cfg.Monitoring.InfluxDB; influx.Enabled {
+		if influx.URL == "" || influx.Org == "" || influx.Bucket == "" || influx.Token == "" {
+			errs = append(errs, fmt.Errorf("monitoring.influxdb requires url, org, bucket and token"))
+		}
+		if influx.BatchSize <= 0 || influx.TimeoutSeconds <= 0 {
+			errs = append(errs, fmt.Errorf("monitoring.influxdb.batch_size and timeout_seconds must be greater than 0"))
+		}
+	}
+
+	if cfg.Heartbeat.TimeoutSeconds <= 0 && (cfg.Heartbeat.URL != "" || cfg.Heartbeat.FailureURL != "") {
+		errs = append(errs, fmt.Errorf("heartbeat.timeout_seconds must be greater than 0"))
+	}
+
+	if cfg.Logging.Format != "text" && cfg.Logging.Format != "json" {
+		errs = append(errs, fmt.Errorf("logging.format must be \"text\" or \"json\", got %q", cfg.Logging.Format))
+	}
+	if err := validateTimezone(cfg.Logging.Timezone); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Logging.MaxSizeMB < 0 || cfg.Logging.MaxBackups < 0 {
+		errs = append(errs, fmt.Errorf("logging.max_size_mb and logging.max_backups cannot be negative"))
+	}
+
+	// Nodes
+	if len(cfg.Node) == 0 {
+		errs = append(errs, fmt.Errorf("no nodes configured"))
+	}
+	for _, node := range cfg.Node {
+		endpoint, err := url.Parse(node.RPCEndpoint)
+		if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+			errs = append(errs, fmt.Errorf("node %q: rpc_endpoint %q is not a valid URL", node.Name, node.RPCEndpoint))
+		}
+		for _, fallback := range node.FallbackEndpoints {
+			u, err := url.Parse(fallback)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				errs = append(errs, fmt.Errorf("node %q: fallback endpoint %q is not a valid URL", node.Name, fallback))
+			}
+		}
+		if node.PrimaryRetryMinutes < 0 {
+			errs = append(errs, fmt.Errorf("node %q: primary_retry_minutes cannot be negative", node.Name))
+		}
+		switch node.Type {
+		case "", "auto", "light", "full", "bridge":
+		default:
+			errs = append(errs, fmt.Errorf("node %q: type must be \"auto\", \"light\", \"full\" or \"bridge\", got %q", node.Name, node.Type))
+		}
+		for _, checkpoint := range node.Checkpoints {
+			if _, err := ParseCheckpoint(checkpoint); err != nil {
+				errs = append(errs, fmt.Errorf("node %q: %w", node.Name, err))
+			}
+		}
+		if len(node.Checkpoints) > 0 && node.CheckpointIntervalMinutes <= 0 {
+			errs = append(errs, fmt.Errorf("node %q: checkpoint_interval_minutes must be greater than 0", node.Name))
+		}
+		if node.StorePath != "" && node.StoreIntervalMinutes <= 0 {
+			errs = append(errs, fmt.Errorf("node %q: store_interval_minutes must be greater than 0", node.Name))
+		}
+		if node.ProcessUnit != "" && node.ProcessPIDFile != "" {
+			errs = append(errs, fmt.Errorf("node %q: process_unit and process_pid_file cannot both be set", node.Name))
+		}
+		if node.RPCRetries < 0 || node.RPCRetryDelayMs < 0 || node.RPCTimeoutSeconds < 0 {
+			errs = append(errs, fmt.Errorf("node %q: RPC retry and timeout settings cannot be negative", node.Name))
+		}
+
+		tls := node.TLS
+		if tls.CACertPath != "" || tls.ClientCertPath != "" || tls.ClientKeyPath != "" || tls.InsecureSkipVerify {
+			if err == nil && endpoint.Scheme != "https" {
+				errs = append(errs, fmt.Errorf("node %q: tls settings require an https:// rpc_endpoint", node.Name))
+			}
+			if (tls.ClientCertPath == "") != (tls.ClientKeyPath == "") {
+				errs = append(errs, fmt.Errorf("node %q: tls.client_cert_path and tls.client_key_path must be set together", node.Name))
+			}
+			for _, path := range []string{tls.CACertPath, tls.ClientCertPath, tls.ClientKeyPath} {
+				if path == "" {
+					continue
+				}
+				if _, err := os.Stat(path); err != nil {
+					errs = append(errs, fmt.Errorf("node %q: tls file %s is not readable: %w", node.Name, path, err))
+				}
+			}
+		}
+	}
+
+	// Thresholds
+	if cfg.Thresholds.SyncStatus.BlocksBehindCritical < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.sync_status.blocks_behind_critical cannot be negative"))
+	}
+	if syncStatus := cfg.Thresholds.SyncStatus; syncStatus.BlocksBehindWarning < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.sync_status.blocks_behind_warning cannot be negative"))
+	} else if syncStatus.BlocksBehindWarning > 0 && syncStatus.BlocksBehindWarning >= syncStatus.BlocksBehindCritical {
+		errs = append(errs, fmt.Errorf("thresholds.sync_status.blocks_behind_warning must be below blocks_behind_critical"))
+	}
+	if cfg.Thresholds.SyncStatus.StallTimeoutSeconds < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.sync_status.stall_timeout_seconds cannot be negative"))
+	}
+	if cfg.Thresholds.SyncStatus.MaxHeadAgeSeconds < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.sync_status.max_head_age_seconds cannot be negative"))
+	}
+	if cfg.Thresholds.Network.MinPeersHealthy < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.network.min_peers_healthy cannot be negative"))
+	}
+	if network := cfg.Thresholds.Network; network.MinPeersWarning < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.network.min_peers_warning cannot be negative"))
+	} else if network.MinPeersWarning > 0 && network.MinPeersWarning <= network.MinPeersHealthy {
+		errs = append(errs, fmt.Errorf("thresholds.network.min_peers_warning must be above min_peers_healthy"))
+	}
+	if cfg.Thresholds.Network.PeerSmoothingChecks < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.network.peer_smoothing_checks cannot be negative"))
+	}
+	if cfg.Thresholds.Network.PeerHysteresis < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.network.peer_hysteresis cannot be negative"))
+	}
+	switch cfg.Thresholds.Network.NATChangeAlert {
+	case "", "off", "any", "from_public":
+	default:
+		errs = append(errs, fmt.Errorf("thresholds.network.nat_change_alert must be \"off\", \"any\" or \"from_public\", got %q", cfg.Thresholds.Network.NATChangeAlert))
+	}
+	if cfg.Thresholds.Bandwidth.MinRateInBytes < 0 || cfg.Thresholds.Bandwidth.MinRateOutBytes < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.bandwidth.min_rate_in_bytes and min_rate_out_bytes cannot be negative"))
+	}
+	if cfg.Thresholds.Bandwidth.LowChecks <= 0 {
+		errs = append(errs, fmt.Errorf("thresholds.bandwidth.low_checks must be greater than 0"))
+	}
+	if sampling := cfg.Thresholds.Sampling; sampling.MaxBehind < 0 || sampling.HeadersBehindCritical < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.sampling.max_behind and headers_behind_critical cannot be negative"))
+	} else if sampling.MaxBehind > 0 && sampling.HeadersBehindCritical > 0 && sampling.HeadersBehindCritical <= sampling.MaxBehind {
+		errs = append(errs, fmt.Errorf("thresholds.sampling.headers_behind_critical must be above max_behind"))
+	}
+	if cfg.Thresholds.Disk.MinFreePercent < 0 || cfg.Thresholds.Disk.MinFreePercent > 100 {
+		errs = append(errs, fmt.Errorf("thresholds.disk.min_free_percent must be between 0 and 100"))
+	}
+	if cfg.Thresholds.Disk.MaxStoreSizeGB < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.disk.max_store_size_gb cannot be negative"))
+	}
+	if cfg.Thresholds.Resources.MaxMemoryMB < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.resources.max_memory_mb cannot be negative"))
+	}
+	if cfg.Thresholds.Process.MaxCPUPercent < 0 || cfg.Thresholds.Process.MaxMemoryMB < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.process.max_cpu_percent and max_memory_mb cannot be negative"))
+	}
+	if cfg.Thresholds.RPC.MaxLatencyMs < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.rpc.max_latency_ms cannot be negative"))
+	}
+	if blob := cfg.Thresholds.Blob; blob.Enabled {
+		if len(blob.Namespace) == 0 || len(blob.Namespace) > 10 {
+			errs = append(errs, fmt.Errorf("thresholds.blob.namespace must be 1 to 10 bytes long"))
+		}
+		if blob.MaxSubmitSeconds <= 0 || blob.IntervalMinutes <= 0 {
+			errs = append(errs, fmt.Errorf("thresholds.blob.max_submit_seconds and thresholds.blob.interval_minutes must be greater than 0"))
+		}
+	}
+
+	// Alerts
+	if cfg.Alerts.AlertCooldownMinutes < 0 {
+		errs = append(errs, fmt.Errorf("alerts.alert_cooldown_minutes cannot be negative"))
+	}
+	if outbox := cfg.Alerts.Outbox; outbox.Enabled && (outbox.MaxEntries <= 0 || outbox.MaxAgeHours <= 0 || outbox.RetrySeconds <= 0) {
+		errs = append(errs, fmt.Errorf("alerts.outbox.max_entries, max_age_hours and retry_seconds must be greater than 0"))
+	}
+	for i, window := range cfg.Alerts.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("alerts.maintenance_windows[%d] %q: %w", i, window.Name, err))
+		}
+	}
+	if cfg.Alerts.History.Enabled && cfg.Alerts.History.MaxSizeMB <= 0 {
+		errs = append(errs, fmt.Errorf("alerts.history.max_size_mb must be greater than 0"))
+	}
+	if cfg.Alerts.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("alerts.max_retries cannot be negative"))
+	}
+	if cfg.Alerts.HTTPTimeoutSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("alerts.http_timeout_seconds must be greater than 0"))
+	}
+	if cfg.Alerts.SendTimeoutSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("alerts.send_timeout_seconds must be greater than 0"))
+	}
+	if cfg.Alerts.UnreachableAfterChecks <= 0 {
+		errs = append(errs, fmt.Errorf("alerts.unreachable_after_checks must be greater than 0"))
+	}
+	if cfg.Alerts.ReminderIntervalMinutes < 0 {
+		errs = append(errs, fmt.Errorf("alerts.reminder_interval_minutes cannot be negative"))
+	}
+	if cfg.Alerts.EscalateAfterMinutes < 0 || cfg.Alerts.EscalateAfterChecks < 0 {
+		errs = append(errs, fmt.Errorf("alerts.escalate_after_minutes and escalate_after_checks cannot be negative"))
+	}
+	if digest := cfg.Alerts.Digest; digest.Enabled && (digest.IntervalHours <= 0 || len(digest.Channels) == 0) {
+		errs = append(errs, fmt.Errorf("alerts.digest requires interval_hours greater than 0 and at least one channel"))
+	}
+	if cfg.Alerts.Enabled && !cfg.AnyAlertChannelEnabled() {
+		errs = append(errs, fmt.Errorf("alerts are enabled but no alert channel is enabled"))
+	}
+
+	if sns := cfg.Alerts.SNS; sns.Enabled && (sns.TopicARN == "") == (sns.PhoneNumber == "") {
+		errs = append(errs, fmt.Errorf("alerts.sns requires exactly one of topic_arn and phone_number"))
+	}
+
+	twilio := cfg.Alerts.Twilio
+	if twilio.Enabled && (twilio.AccountSID == "" || twilio.AuthToken == "" || twilio.FromNumber == "" || len(twilio.ToNumbers) == 0) {
+		errs = append(errs, fmt.Errorf("alerts.twilio requires account_sid, auth_token, from_number and to_number"))
+	}
+
+	return errs
+}
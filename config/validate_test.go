@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigWithoutWarningBands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configFile, err := ConfigFile()
+	if err != nil {
+		t.Fatalf("ConfigFile: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Thresholds written before the warning bands existed must still load
+	data := []byte(`node:
+  - name: light
+    rpc_url: http://localhost:26658
+thresholds:
+  sync_status:
+    blocks_behind_critical: 3
+  network:
+    min_peers_healthy: 15
+`)
+	if err := os.WriteFile(configFile, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if errs := Validate(cfg); len(errs) != 0 {
+		t.Errorf("Validate: %v", errs)
+	}
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Color is an ANSI terminal color code
+type Color string
+
+// Colors of the health indicators
+const (
+	ColorGreen  Color = "32"
+	ColorYellow Color = "33"
+	ColorRed    Color = "31"
+)
+
+// ColorEnabled reports whether stdout output may be colored. Color is off
+// with noColor, when the NO_COLOR environment variable is set
+// (https://no-color.org) or when stdout is not a terminal.
+func ColorEnabled(noColor bool) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// Paint wraps text in the escape codes of color when enabled, and returns
+// it unchanged otherwise
+func Paint(enabled bool, color Color, text string) string {
+	if !enabled {
+		return text
+	}
+	return "\033[" + string(color) + "m" + text + "\033[0m"
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Supported log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// New creates a logger writing to w in the given format. The text format
+// prints "[LEVEL] message" lines and leaves the structured fields to the
+// JSON format.
+func New(w io.Writer, format string) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		return slog.New(&textHandler{w: w, mu: &sync.Mutex{}}), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q, expected %q or %q", format, FormatText, FormatJSON)
+	}
+}
+
+// Output returns the writer log lines go to: stdout, plus a size rotated
+// file if file is not empty
+func Output(file string, maxSizeMB, maxBackups int) io.Writer {
+	if file == "" {
+		return os.Stdout
+	}
+
+	return io.MultiWriter(os.Stdout, &lumberjack.Logger{
+		Filename:   file,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+	})
+}
+
+// textHandler renders records in the original watchtower console format
+type textHandler struct {
+	w  io.Writer
+	mu *sync.Mutex
+}
+
+// Enabled reports whether the handler handles records at the given level
+func (h *textHandler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+// Handle writes the record as a single "[LEVEL] message" line
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := fmt.Fprintf(h.w, "[%s] %s\n", levelName(r.Level), r.Message)
+	return err
+}
+
+// WithAttrs returns the handler unchanged, fields are only rendered in JSON
+func (h *textHandler) WithAttrs(_ []slog.Attr) slog.Handler {
+	return h
+}
+
+// WithGroup returns the handler unchanged, fields are only rendered in JSON
+func (h *textHandler) WithGroup(_ string) slog.Handler {
+	return h
+}
+
+// levelName maps slog levels to the watchtower level labels
+func levelName(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARN"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
@@ -0,0 +1,158 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/21state/celestia-watchtower/alert"
+)
+
+// startTelegramPoller listens for acknowledgements and commands sent to the
+// Telegram bot of the given alert manager, replacing the poller of a
+// previous manager
+func (e *Engine) startTelegramPoller(alerter *alert.Manager) {
+	if e.stopPoller != nil {
+		e.stopPoller()
+		e.stopPoller = nil
+	}
+
+	telegram := e.config.Alerts.Telegram
+	if !e.config.Alerts.Enabled || !telegram.Enabled || !telegram.Interactive || e.sink != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.stopPoller = cancel
+	go alerter.PollTelegram(ctx, telegramHandler{e}, func(err error) {
+		e.log.Warn(fmt.Sprintf("Telegram bot: %v", err), "error", err)
+	})
+	e.log.Info("Listening for Telegram acknowledgements and commands")
+}
+
+// telegramHandler answers the Telegram bot for the engine
+type telegramHandler struct {
+	e *Engine
+}
+
+// Acknowledge implements alert.TelegramHandler
+func (h telegramHandler) Acknowledge(ack alert.TelegramAck) string {
+	return h.e.acknowledge(ack, time.Now())
+}
+
+// Status implements alert.TelegramHandler
+func (h telegramHandler) Status() string {
+	return h.e.statusReport()
+}
+
+// acknowledge marks the unresolved problem of a node acknowledged, or of
+// every alerting node without ack.Node. That holds back repeated alerts
+// until the node recovers or the acknowledgement runs out. It returns the
+// reply posted to the chat.
+func (e *Engine) acknowledge(ack alert.TelegramAck, now time.Time) string {
+	var nodes []*nodeMonitor
+	for _, n := range e.nodes {
+		if ack.Node == "" || n.name == ack.Node {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		return fmt.Sprintf("Unknown node %q", ack.Node)
+	}
+
+	held := "until it recovers"
+	var until time.Time
+	if ack.For > 0 {
+		until = now.Add(ack.For)
+		held = fmt.Sprintf("for %s", ack.For)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var replies []string
+	for _, n := range nodes {
+		switch {
+		case !n.alerting:
+			if ack.Node != "" {
+				replies = append(replies, fmt.Sprintf("%s has no open alert to acknowledge", n.config.DisplayName()))
+			}
+			continue
+		case ack.For == 0 && n.acknowledgedBy != "" && n.acknowledgedUntil.IsZero():
+			// A timed acknowledgement may be replaced, one until recovery is final
+			replies = append(replies, fmt.Sprintf("%s was already acknowledged by %s", n.config.DisplayName(), n.acknowledgedBy))
+			continue
+		}
+
+		n.acknowledgedBy = ack.By
+		n.acknowledgedUntil = until
+		e.log.Info(fmt.Sprintf("[%s] Alert acknowledged by %s %s", n.name, ack.By, held), "node", n.name, "acknowledged_by", ack.By, "acknowledged_for", ack.For.String())
+		replies = append(replies, fmt.Sprintf("✅ %s acknowledged the alert for %s, repeat alerts are held back %s", ack.By, n.config.DisplayName(), held))
+	}
+
+	if len(replies) == 0 {
+		return "No open alerts to acknowledge"
+	}
+	return strings.Join(replies, "\n")
+}
+
+// acknowledged returns who acknowledged the node's unresolved problem,
+// empty if nobody did or the acknowledgement ran out
+func (e *Engine) acknowledged(n *nodeMonitor) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if !n.acknowledgedUntil.IsZero() && time.Now().After(n.acknowledgedUntil) {
+		return ""
+	}
+	return n.acknowledgedBy
+}
+
+// statusReport summarizes the last status of every node for the /status
+// command
+func (e *Engine) statusReport() string {
+	statuses := e.GetLastStatus()
+
+	var b strings.Builder
+	for _, n := range e.nodes {
+		status, ok := statuses[n.name]
+		if !ok {
+			fmt.Fprintf(&b, "📡 %s: not checked yet\n\n", n.config.DisplayName())
+			continue
+		}
+
+		fmt.Fprintf(&b, "📡 %s: %s\n", n.config.DisplayName(), status.Indicator())
+		fmt.Fprintf(&b, "   Height: %d/%d (%s)\n", status.LocalHeight, status.NetworkHeight, status.HeightSummary())
+		fmt.Fprintf(&b, "   Peers: %d | NAT: %s\n", status.PeerCount, status.NATStatus)
+		fmt.Fprintf(&b, "   Checked: %s ago\n", time.Since(status.Timestamp).Round(time.Second))
+		if acknowledgedBy := e.acknowledged(n); acknowledgedBy != "" {
+			fmt.Fprintf(&b, "   Acknowledged by: %s\n", acknowledgedBy)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// startEpisode records that a problem alert of the node went out
+func (e *Engine) startEpisode(n *nodeMonitor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n.alerting = true
+}
+
+// alertingNode reports whether a problem alert of the node is unresolved
+func (e *Engine) alertingNode(n *nodeMonitor) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return n.alerting
+}
+
+// endEpisode resolves the node's problem along with its acknowledgement
+func (e *Engine) endEpisode(n *nodeMonitor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n.alerting = false
+	n.lastReminder = time.Time{}
+	n.acknowledgedBy = ""
+	n.acknowledgedUntil = time.Time{}
+}
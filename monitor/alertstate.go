@@ -0,0 +1,143 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/21state/celestia-watchtower/alert"
+)
+
+// sentAlert records the last alert sent for a category of a node
+type sentAlert struct {
+	Fingerprint  string         `json:"fingerprint"`
+	Severity     alert.Severity `json:"-"`
+	SeverityName string         `json:"severity"`
+	SentAt       time.Time      `json:"sent_at"`
+}
+
+// nodeAlertState is the alerting state of a node that survives restarts
+type nodeAlertState struct {
+	UnhealthySince    time.Time            `json:"unhealthy_since,omitempty"`
+	UnhealthyChecks   int                  `json:"unhealthy_checks,omitempty"`
+	EpisodeSeverity   string               `json:"episode_severity,omitempty"`
+	Escalated         bool                 `json:"escalated,omitempty"`
+	LastReminder      time.Time            `json:"last_reminder,omitempty"`
+	Alerting          bool                 `json:"alerting,omitempty"`
+	AcknowledgedBy    string               `json:"acknowledged_by,omitempty"`
+	AcknowledgedUntil time.Time            `json:"acknowledged_until,omitempty"`
+	Sent              map[string]sentAlert `json:"sent,omitempty"`
+}
+
+// alertFingerprint identifies an alert for deduplication. Alerts with the
+// same fingerprint are not re-sent within the cooldown.
+func alertFingerprint(node, category string, severity alert.Severity) string {
+	return fmt.Sprintf("%s/%s/%s", node, category, severity)
+}
+
+// saveAlertState writes the cooldown state of each node to the alert state file
+func (e *Engine) saveAlertState() error {
+	stateFile, err := e.config.AlertStateFilePath()
+	if err != nil {
+		return err
+	}
+
+	states := make(map[string]nodeAlertState, len(e.nodes))
+	for _, n := range e.nodes {
+		e.mu.RLock()
+		state := nodeAlertState{
+			UnhealthySince:    n.unhealthySince,
+			UnhealthyChecks:   n.unhealthyChecks,
+			Escalated:         n.escalated,
+			LastReminder:      n.lastReminder,
+			Alerting:          n.alerting,
+			AcknowledgedBy:    n.acknowledgedBy,
+			AcknowledgedUntil: n.acknowledgedUntil,
+			Sent:              make(map[string]sentAlert, len(n.lastAlertSent)),
+		}
+		e.mu.RUnlock()
+		if n.episodeSeverity > 0 {
+			state.EpisodeSeverity = n.episodeSeverity.String()
+		}
+		for category, sent := range n.lastAlertSent {
+			sent.SeverityName = sent.Severity.String()
+			state.Sent[category] = sent
+		}
+		states[n.name] = state
+	}
+
+	if err := os.MkdirAll(filepath.Dir(stateFile), 0755); err != nil {
+		return fmt.Errorf("failed to create alert state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert state: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial file
+	tmpFile := stateFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write alert state file: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, stateFile); err != nil {
+		return fmt.Errorf("failed to replace alert state file: %w", err)
+	}
+
+	return nil
+}
+
+// loadAlertState restores the cooldown state of each node from the alert
+// state file. A missing file leaves the nodes with a fresh state.
+func (e *Engine) loadAlertState() error {
+	stateFile, err := e.config.AlertStateFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read alert state file: %w", err)
+	}
+
+	states := make(map[string]nodeAlertState)
+	if err := json.Unmarshal(data, &states); err != nil {
+		return fmt.Errorf("failed to parse alert state file: %w", err)
+	}
+
+	for _, n := range e.nodes {
+		state, ok := states[n.name]
+		if !ok {
+			continue
+		}
+
+		n.unhealthySince = state.UnhealthySince
+		n.unhealthyChecks = state.UnhealthyChecks
+		n.escalated = state.Escalated
+		n.lastReminder = state.LastReminder
+		n.alerting = state.Alerting
+		n.acknowledgedBy = state.AcknowledgedBy
+		n.acknowledgedUntil = state.AcknowledgedUntil
+		if state.EpisodeSeverity != "" {
+			if severity, err := alert.ParseSeverity(state.EpisodeSeverity); err == nil {
+				n.episodeSeverity = severity
+			}
+		}
+		for category, sent := range state.Sent {
+			severity, err := alert.ParseSeverity(sent.SeverityName)
+			if err != nil {
+				continue
+			}
+			sent.Severity = severity
+			n.lastAlertSent[category] = sent
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/21state/celestia-watchtower/alert"
+)
+
+// digestStats accumulates the checks of a node since the last digest
+type digestStats struct {
+	checks        int   // checks run, failed ones included
+	healthyChecks int   // checks that found the node healthy
+	failedChecks  int   // checks that could not reach the node
+	heightDiffSum int64 // sum of the height differences of the successful checks
+	minPeers      int
+	maxPeers      int
+	peersSeen     bool // minPeers and maxPeers are set
+
+	// Bytes moved since the period started. The node reports totals since
+	// it started, so a restart is detected by a total going back.
+	bytesIn, bytesOut         int64
+	lastTotalIn, lastTotalOut int64
+	totalsSeen                bool // the last totals are set
+}
+
+// recordDigest adds a successful check to the node's digest
+func (e *Engine) recordDigest(n *nodeMonitor, status *Status) {
+	if !e.config.Alerts.Digest.Enabled {
+		return
+	}
+
+	d := &n.digest
+	d.checks++
+	if status.Healthy {
+		d.healthyChecks++
+	}
+	d.heightDiffSum += status.BlocksBehind()
+
+	// Readings of a failed query are left out
+	if !status.queryFailed(subsystemPeers) {
+		if !d.peersSeen {
+			d.minPeers, d.maxPeers = status.PeerCount, status.PeerCount
+			d.peersSeen = true
+		}
+		d.minPeers = min(d.minPeers, status.PeerCount)
+		d.maxPeers = max(d.maxPeers, status.PeerCount)
+	}
+	if status.queryFailed(subsystemBandwidth) {
+		return
+	}
+
+	// The first check only sets the starting totals
+	if d.totalsSeen {
+		d.bytesIn += bytesSince(d.lastTotalIn, status.Bandwidth.TotalIn)
+		d.bytesOut += bytesSince(d.lastTotalOut, status.Bandwidth.TotalOut)
+	}
+	d.lastTotalIn, d.lastTotalOut = status.Bandwidth.TotalIn, status.Bandwidth.TotalOut
+	d.totalsSeen = true
+}
+
+// bytesSince returns the bytes moved between two totals reported by the
+// node, counting from zero if the node restarted in between
+func bytesSince(last, total int64) int64 {
+	if total < last {
+		return total
+	}
+	return total - last
+}
+
+// recordDigestFailure adds a failed check to the node's digest
+func (e *Engine) recordDigestFailure(n *nodeMonitor) {
+	if !e.config.Alerts.Digest.Enabled {
+		return
+	}
+	n.digest.checks++
+	n.digest.failedChecks++
+}
+
+// sendDigestIfDue sends the digest of every node once
+// alerts.digest.interval_hours passed since the last one, and starts the
+// next period. The period restarts with the watchtower.
+func (e *Engine) sendDigestIfDue(now time.Time) error {
+	digest := e.config.Alerts.Digest
+	if !digest.Enabled || !e.config.Alerts.Enabled {
+		return nil
+	}
+	if e.digestSince.IsZero() {
+		e.digestSince = now
+		return nil
+	}
+	if now.Sub(e.digestSince) < time.Duration(digest.IntervalHours)*time.Hour {
+		return nil
+	}
+
+	message := fmt.Sprintf("📊 Digest from %s to %s\n", e.config.FormatTime(e.digestSince), e.config.FormatTime(now))
+	for _, n := range e.nodes {
+		message += "\n" + e.digestSection(n)
+		// Totals carry over so the next period counts from them
+		n.digest = digestStats{lastTotalIn: n.digest.lastTotalIn, lastTotalOut: n.digest.lastTotalOut, totalsSeen: n.digest.totalsSeen}
+	}
+	e.digestSince = now
+
+	_, err := e.send(alert.Alert{
+		Kind:      alert.KindDigest,
+		Severity:  alert.SeverityInfo,
+		Message:   message,
+		Timestamp: now,
+	})
+	return err
+}
+
+// digestSection summarizes the checks of a node since the last digest
+func (e *Engine) digestSection(n *nodeMonitor) string {
+	d := n.digest
+	name := n.config.DisplayName()
+	if d.checks == 0 {
+		return fmt.Sprintf("[%s] No checks\n", name)
+	}
+
+	var lines []string
+	lines = append(lines, fmt.Sprintf("[%s] Uptime: %.1f%% (%d of %d checks healthy)",
+		name, 100*float64(d.healthyChecks)/float64(d.checks), d.healthyChecks, d.checks))
+	if d.failedChecks > 0 {
+		lines = append(lines, fmt.Sprintf("Failed Checks: %d", d.failedChecks))
+	}
+
+	if reached := d.checks - d.failedChecks; reached > 0 {
+		in, inUnit := formatDataSize(float64(d.bytesIn))
+		out, outUnit := formatDataSize(float64(d.bytesOut))
+		lines = append(lines, fmt.Sprintf("Average Height Difference: %.1f", float64(d.heightDiffSum)/float64(reached)))
+		if d.peersSeen {
+			lines = append(lines, fmt.Sprintf("Peers: %d to %d", d.minPeers, d.maxPeers))
+		}
+		lines = append(lines, fmt.Sprintf("Bandwidth: %.2f %s in, %.2f %s out", in, inUnit, out, outUnit))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
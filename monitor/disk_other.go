@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package monitor
+
+import "fmt"
+
+// diskUsage is not supported on this platform
+func diskUsage(path string) (uint64, float64, error) {
+	return 0, 0, fmt.Errorf("disk usage check is not supported on this platform")
+}
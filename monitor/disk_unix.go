@@ -0,0 +1,26 @@
+//go:build linux || darwin
+
+package monitor
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// diskUsage returns the free bytes and percent used of the filesystem holding path
+func diskUsage(path string) (uint64, float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("failed to stat filesystem of %s: %w", path, err)
+	}
+
+	blockSize := uint64(stat.Bsize)
+	free := stat.Bavail * blockSize
+	used := (stat.Blocks - stat.Bfree) * blockSize
+
+	// Match df: reserved blocks count as neither used nor available
+	if used+free == 0 {
+		return free, 0, nil
+	}
+	return free, float64(used) / float64(used+free) * 100, nil
+}
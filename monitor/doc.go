@@ -0,0 +1,16 @@
+// Package monitor checks Celestia nodes and raises alerts about them. It
+// can be embedded in other Go programs:
+//
+//	cfg, err := config.LoadConfig()
+//	...
+//	engine, err := monitor.New(cfg, monitor.Options{Logger: logger, AlertSink: sink})
+//	...
+//	defer engine.Close()
+//	status, err := engine.CheckOnce(ctx)
+//
+// The stable surface is New, NewEngine, Options, AlertSink, Status,
+// BlobCheck, CheckNodeStatus and NewNodeClient, and the Engine methods
+// CheckOnce, CheckNodeOnce, RunOnce, Start, Stop, Close, GetLastStatus and
+// StartHTTPServer, together with config.Config and alert.Alert. Other
+// exported identifiers serve the command line tool and may change.
+package monitor
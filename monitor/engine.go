@@ -2,84 +2,357 @@ package monitor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/21state/celestia-watchtower/alert"
 	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/logging"
 	"github.com/21state/celestia-watchtower/rpc"
 )
 
-// Engine is responsible for monitoring the node
+// Engine is responsible for monitoring the nodes
 type Engine struct {
-	client      *rpc.Client
+	nodes       []*nodeMonitor
 	config      *config.Config
 	alerter     *alert.Manager
+	sink        AlertSink // replaces the alerter for alerts if set
 	ctx         context.Context
 	cancel      context.CancelFunc
-	lastStatus  *Status
+	debug       bool
+	color       bool // color the status output
+	log         *slog.Logger
+
+	// influx batches the points written to InfluxDB
+	influx influxWriter
+
+	// stopPoller stops the Telegram acknowledgement poller, if running
+	stopPoller context.CancelFunc
+
+	// digestSince is when the current digest period started
+	digestSince time.Time
+
+	// once is set by RunOnce, which has no later check to pick up a blob
+	// submission running in the background, so it waits for it
+	once bool
+
+	// mu guards the last status of each node, which the HTTP server reads,
+	// and the config and alerter swapped by a reload, which the outbox
+	// sender reads
+	mu sync.RWMutex
 }
 
-// NewEngine creates a new monitoring engine
+// nodeMonitor holds the RPC client and per-node state of a monitored node
+type nodeMonitor struct {
+	name       string
+	config     config.NodeConfig
+	client     *rpc.Client
+	lastStatus *Status
+
+	// unhealthySince is when the node last transitioned to unhealthy
+	unhealthySince time.Time
+
+	// unhealthyChecks counts the consecutive unhealthy checks
+	unhealthyChecks int
+
+	// lastAlertSent tracks the last alert sent per category
+	lastAlertSent map[string]sentAlert
+
+	// episodeSeverity is the highest severity alerted in the current unhealthy period
+	episodeSeverity alert.Severity
+
+	// escalated is set once alerts of the current unhealthy period were escalated
+	escalated bool
+
+	// lastReminder is when the last reminder of the current unhealthy period went out
+	lastReminder time.Time
+
+	// pendingRecovery is a recovery of an alerted problem that a
+	// maintenance window or silence held back, sent once alerts go out again
+	pendingRecovery *alert.Alert
+
+	// alerting is set while a problem alert of the node is unresolved, and
+	// acknowledgedBy names who acknowledged it, until acknowledgedUntil if
+	// the acknowledgement expires. They are guarded by Engine.mu since
+	// acknowledgements arrive from the Telegram poller.
+	alerting          bool
+	acknowledgedBy    string
+	acknowledgedUntil time.Time
+
+	// firstSeen is when the current node process was first seen
+	firstSeen time.Time
+
+	// heightChangedAt is when the local height last changed, for stall detection
+	heightChangedAt time.Time
+
+	// lowBandwidthChecks counts the consecutive checks below a bandwidth threshold
+	lowBandwidthChecks int
+
+	// partialChecks counts the consecutive checks with failed queries
+	partialChecks int
+
+	// peerAverage is the moving average of the peer count, set once
+	// peerAverageSet is, and lowPeers whether it is below the minimum
+	peerAverage    float64
+	peerAverageSet bool
+	lowPeers       bool
+
+	// recent holds the statuses of the last trendChecks checks, oldest
+	// first, for the trend in alerts
+	recent []*Status
+
+	// checkDurations holds the durations of the last checks in
+	// milliseconds, for the latency moving average
+	checkDurations []int64
+
+	// aheadChecks counts the consecutive checks with the local head ahead
+	// of the network head
+	aheadChecks int
+
+	// failedChecks counts the consecutive checks that failed with an error
+	failedChecks int
+
+	// connLost is set once failedChecks reaches alerts.unreachable_after_checks
+	connLost bool
+
+	// connAlerted is set once an unreachable alert was delivered, so the
+	// recovery is only announced to those who heard about the outage
+	connAlerted bool
+
+	// lastBlob is the latest blob submission check and blobRunning is set
+	// while a submission is waiting to be included, both guarded by blobMu
+	blobMu      sync.Mutex
+	lastBlob    *BlobCheck
+	blobRunning bool
+
+	// lastCheckpoint is the latest verification of the trusted checkpoints
+	lastCheckpoint *CheckpointCheck
+
+	// digest accumulates the checks since the last digest
+	digest digestStats
+
+	// storeSamples holds the store size measurements of the last day, oldest first
+	storeSamples []storeSample
+
+	// process is the previous reading of the node process, for its CPU use
+	process processSample
+
+	// reconnectAttempts and nextReconnect back off reconnecting a lost connection
+	reconnectAttempts int
+	nextReconnect     time.Time
+}
+
+// Delays between attempts to reconnect a lost RPC connection
+const (
+	reconnectMinDelay = 5 * time.Second
+	reconnectMaxDelay = 5 * time.Minute
+)
+
+// Alert categories used for cooldown tracking
+const (
+	alertCategoryChain     = "chain"
+	alertCategorySync      = "sync"
+	alertCategoryNetwork   = "network"
+	alertCategoryBandwidth = "bandwidth"
+	alertCategorySampling  = "sampling"
+	alertCategoryDisk      = "disk"
+	alertCategoryStore     = "store"
+	alertCategoryResources = "resources"
+	alertCategoryProcess   = "process"
+	alertCategoryBlob      = "blob"
+	alertCategoryLatency   = "latency"
+	alertCategoryQueries   = "queries"
+	alertCategoryRestart   = "restart"
+	alertCategoryRPC       = "rpc"
+	alertCategoryNAT       = "nat"
+)
+
+// Options configures an engine created with New
+type Options struct {
+	Logger    *slog.Logger // engine output, nil uses slog.Default()
+	Debug     bool         // log detailed status of every check
+	Color     bool         // color the health indicators of the status output
+	AlertSink AlertSink    // receives alerts instead of the configured channels if set
+}
+
+// AlertSink receives the alerts raised by the engine
+type AlertSink interface {
+	Send(a alert.Alert) error
+}
+
+// NewEngine creates a new monitoring engine with the default options
 func NewEngine(cfg *config.Config) (*Engine, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	return New(cfg, Options{})
+}
 
+// New creates a new monitoring engine
+func New(cfg *config.Config, opts Options) (*Engine, error) {
 	// Validate configuration
 	if cfg == nil {
 		return nil, fmt.Errorf("[ERROR] configuration is nil")
 	}
 
-	// Validate RPC endpoint
-	if cfg.Node.RPCEndpoint == "" {
-		return nil, fmt.Errorf("[ERROR] RPC endpoint cannot be empty")
+	if len(cfg.Node) == 0 {
+		return nil, fmt.Errorf("[ERROR] no nodes configured")
 	}
 
-	client, err := rpc.NewClient(ctx, cfg.Node.RPCEndpoint, cfg.Node.AuthToken)
+	// Validate RPC endpoints
+	for _, node := range cfg.Node {
+		if node.RPCEndpoint == "" {
+			return nil, fmt.Errorf("[ERROR] RPC endpoint for node %q cannot be empty", node.Name)
+		}
+	}
+
+	alerter, err := alert.NewManager(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] failed to create RPC client: %w", err)
+		return nil, fmt.Errorf("[ERROR] failed to create alert manager: %w", err)
 	}
+	alerter.SetStatusDecoder(decodeStatus)
 
-	alerter := alert.NewManager(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Engine{
-		client:      client,
+	e := &Engine{
 		config:      cfg,
 		alerter:     alerter,
+		sink:        opts.AlertSink,
 		ctx:         ctx,
 		cancel:      cancel,
-	}, nil
+		debug:       opts.Debug,
+		color:       opts.Color,
+		log:         slog.Default(),
+	}
+	if opts.Logger != nil {
+		e.log = opts.Logger
+	}
+
+	for _, node := range cfg.Node {
+		if !processSupported && (node.ProcessUnit != "" || node.ProcessPIDFile != "") {
+			e.log.Warn(fmt.Sprintf("[%s] Process check is only supported on Linux, skipping it", node.Name), "node", node.Name)
+		}
+		client, err := NewNodeClient(ctx, node, e.log)
+		if err != nil {
+			e.closeClients()
+			cancel()
+			return nil, fmt.Errorf("[ERROR] failed to create RPC client for node %q: %w", node.Name, err)
+		}
+
+		e.nodes = append(e.nodes, &nodeMonitor{
+			name:          node.Name,
+			config:        node,
+			client:        client,
+			lastAlertSent: make(map[string]sentAlert),
+		})
+	}
+
+	// Restore cooldowns so a restart does not re-send recent alerts
+	if err := e.loadAlertState(); err != nil {
+		e.closeClients()
+		cancel()
+		return nil, fmt.Errorf("[ERROR] %w", err)
+	}
+
+	return e, nil
+}
+
+// NewNodeClient creates an RPC client for a node using its configured
+// retry, timeout, TLS and fallback settings. Switches between endpoints
+// are logged to log.
+func NewNodeClient(ctx context.Context, node config.NodeConfig, log *slog.Logger) (*rpc.Client, error) {
+	return rpc.NewClient(ctx, node.RPCEndpoint, node.AuthToken, rpc.Options{
+		Retries:    node.RPCRetries,
+		RetryDelay: time.Duration(node.RPCRetryDelayMs) * time.Millisecond,
+		Timeout:    time.Duration(node.RPCTimeoutSeconds) * time.Second,
+		TLS: rpc.TLSOptions{
+			CACertPath:         node.TLS.CACertPath,
+			ClientCertPath:     node.TLS.ClientCertPath,
+			ClientKeyPath:      node.TLS.ClientKeyPath,
+			InsecureSkipVerify: node.TLS.InsecureSkipVerify,
+		},
+		Fallbacks:    node.FallbackEndpoints,
+		PrimaryRetry: time.Duration(node.PrimaryRetryMinutes) * time.Minute,
+		OnSwitch: func(from, to string, cause error) {
+			if cause == nil {
+				log.Info(fmt.Sprintf("[%s] Switched back to the primary RPC endpoint %s", node.Name, to),
+					"node", node.Name, "from", from, "endpoint", to)
+				return
+			}
+			log.Warn(fmt.Sprintf("[%s] RPC endpoint %s failed, switched to %s: %v", node.Name, from, to, cause),
+				"node", node.Name, "from", from, "endpoint", to, "error", cause)
+		},
+	})
+}
+
+// closeClients closes the RPC clients of all nodes
+func (e *Engine) closeClients() {
+	for _, n := range e.nodes {
+		n.client.Close()
+	}
 }
 
 // Start starts the monitoring engine
 func (e *Engine) Start() error {
-	fmt.Println("[INFO] 🔭 Celestia Watchtower started")
-	fmt.Printf("[INFO] Monitoring %s every %d seconds\n", e.config.Node.RPCEndpoint, e.config.Monitoring.CheckInterval)
+	e.log.Info("🔭 Celestia Watchtower started")
+	for _, node := range e.config.Node {
+		e.log.Info(fmt.Sprintf("[%s] Monitoring %s every %d seconds", node.Name, node.RPCEndpoint, e.config.Monitoring.CheckInterval),
+			"node", node.Name, "endpoint", node.RPCEndpoint, "interval_seconds", e.config.Monitoring.CheckInterval)
+	}
 
 	// Set up signal handling for graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
+	// SIGHUP reloads the configuration without dropping the RPC connections
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
 	// Create ticker for periodic checks
 	ticker := time.NewTicker(time.Duration(e.config.Monitoring.CheckInterval) * time.Second)
 	defer ticker.Stop()
 
+	// Resume delivering alerts queued before a restart
+	go e.runOutbox()
+
+	// Listen for alerts acknowledged in Telegram
+	e.startTelegramPoller(e.alerter)
+
 	// Initial check
 	if err := e.runCheck(); err != nil {
-		logError("Initial check failed: %v", err)
+		e.log.Error(fmt.Sprintf("Initial check failed: %v", err), "error", err)
 	}
 
 	// Main loop
 	for {
 		select {
 		case <-ticker.C:
+			// Spread checks of many watchtowers sharing an RPC endpoint
+			if jitter := e.jitter(); jitter > 0 {
+				select {
+				case <-time.After(jitter):
+				case <-sigCh:
+					e.log.Info("Shutting down...")
+					e.Stop()
+					return nil
+				case <-e.ctx.Done():
+					return nil
+				}
+			}
 			if err := e.runCheck(); err != nil {
-				logError("Check failed: %v", err)
+				e.log.Error(fmt.Sprintf("Check failed: %v", err), "error", err)
 			}
+		case <-hupCh:
+			e.reload(ticker)
 		case <-sigCh:
-			fmt.Println("[INFO] Shutting down...")
+			e.log.Info("Shutting down...")
 			e.Stop()
 			return nil
 		case <-e.ctx.Done():
@@ -88,40 +361,763 @@ func (e *Engine) Start() error {
 	}
 }
 
-// Stop stops the monitoring engine
+// jitter returns a random delay of up to the configured jitter
+func (e *Engine) jitter() time.Duration {
+	max := time.Duration(e.config.Monitoring.JitterSeconds) * time.Second
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+// RunOnce checks every node a single time, sending any needed alerts,
+// and reports whether all nodes are healthy
+func (e *Engine) RunOnce() (bool, error) {
+	e.once = true
+	e.drainOutbox()
+	err := e.runCheck()
+	return e.allHealthy(), err
+}
+
+// CheckOnce checks the first configured node and returns its status,
+// without logging, alerting or recording it
+func (e *Engine) CheckOnce(ctx context.Context) (*Status, error) {
+	return e.CheckNodeOnce(ctx, e.nodes[0].name)
+}
+
+// CheckNodeOnce checks the named node and returns its status, without
+// logging, alerting or recording it. Checks that compare against earlier
+// checks, such as stall detection and the blob check, are not included.
+func (e *Engine) CheckNodeOnce(ctx context.Context, name string) (*Status, error) {
+	var n *nodeMonitor
+	for _, node := range e.nodes {
+		if node.name == name {
+			n = node
+		}
+	}
+	if n == nil {
+		return nil, fmt.Errorf("[ERROR] unknown node %q", name)
+	}
+
+	type result struct {
+		status *Status
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		status, err := CheckNodeStatus(n.client, e.config, n.config)
+		done <- result{status, err}
+	}()
+
+	// The RPC calls are bounded by the node's timeout, but the caller may
+	// give up earlier
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		r.status.Node = n.name
+		r.status.Label = n.config.Label
+		return r.status, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runOutbox retries queued alert deliveries until the engine stops. It
+// keeps running while the outbox is disabled so a reload can enable it.
+func (e *Engine) runOutbox() {
+	e.drainOutbox()
+
+	for {
+		select {
+		case <-time.After(e.outboxRetryInterval()):
+			e.drainOutbox()
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+// outboxRetryInterval returns the delay between outbox delivery rounds
+func (e *Engine) outboxRetryInterval() time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if seconds := e.config.Alerts.Outbox.RetrySeconds; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Minute
+}
+
+// drainOutbox makes one attempt to deliver the queued alerts
+func (e *Engine) drainOutbox() {
+	e.mu.RLock()
+	alerter := e.alerter
+	e.mu.RUnlock()
+
+	result, err := alerter.DrainOutbox()
+	if err != nil {
+		e.log.Error(fmt.Sprintf("Alert outbox failed: %v", err), "error", err)
+	}
+	if result.Delivered > 0 || result.Dropped > 0 {
+		e.log.Info(fmt.Sprintf("Alert outbox: %d delivered, %d dropped, %d pending", result.Delivered, result.Dropped, result.Pending),
+			"delivered", result.Delivered, "dropped", result.Dropped, "pending", result.Pending)
+	}
+}
+
+// SetLogger sets the logger used for engine output
+func (e *Engine) SetLogger(logger *slog.Logger) {
+	e.log = logger
+}
+
+// SetDebug enables or disables detailed debug output
+func (e *Engine) SetDebug(debug bool) {
+	e.debug = debug
+}
+
+// Stop stops the monitoring engine, writing out the buffered InfluxDB points
 func (e *Engine) Stop() {
+	if err := e.flushInfluxDB(); err != nil {
+		e.log.Error(fmt.Sprintf("InfluxDB flush failed: %v", err), "error", err)
+	}
 	e.cancel()
 }
 
-// GetLastStatus returns the last known status
-func (e *Engine) GetLastStatus() *Status {
-	return e.lastStatus
+// Close stops the engine and closes the RPC clients
+func (e *Engine) Close() {
+	e.Stop()
+	e.closeClients()
 }
 
-// runCheck performs a single check of the node status
+// GetLastStatus returns the last known status of each node, keyed by node name
+func (e *Engine) GetLastStatus() map[string]*Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	statuses := make(map[string]*Status, len(e.nodes))
+	for _, n := range e.nodes {
+		if n.lastStatus != nil {
+			statuses[n.name] = n.lastStatus
+		}
+	}
+	return statuses
+}
+
+// allHealthy reports whether every node was checked and found healthy
+func (e *Engine) allHealthy() bool {
+	statuses := e.GetLastStatus()
+	if len(statuses) < len(e.nodes) {
+		return false
+	}
+
+	for _, status := range statuses {
+		if !status.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// runCheck performs a single check of every node
 func (e *Engine) runCheck() error {
+	var errs []string
+	var checked []*Status
+	for _, n := range e.nodes {
+		previous := n.lastStatus
+		if err := e.checkNode(n); err != nil {
+			errs = append(errs, fmt.Sprintf("[%s] %v", n.name, err))
+		}
+		if n.lastStatus != previous {
+			checked = append(checked, n.lastStatus)
+		}
+	}
+
+	// Persist the latest statuses for the status command
+	statuses := e.GetLastStatus()
+	if err := SaveStatus(e.config, statuses); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to save status: %v", err))
+	}
+
+	// Persist cooldowns so they survive restarts
+	if err := e.saveAlertState(); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to save alert state: %v", err))
+	}
+
+	// Publish them to subscribers such as MQTT dashboards
+	if err := e.alerter.PublishStatus(statuses); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to publish status: %v", err))
+	}
+
+	// Record this round of checks for the history command
+	if len(checked) > 0 {
+		if err := AppendHistory(e.config, checked); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to save history: %v", err))
+		}
+	}
+
+	// Export them for long-term metrics
+	if err := e.exportInfluxDB(checked); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to export to InfluxDB: %v", err))
+	}
+
+	// Summarize the period once the digest is due
+	if err := e.sendDigestIfDue(time.Now()); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to send digest: %v", err))
+	}
+
+	// Let the dead man's switch know the watchtower is alive
+	e.pingHeartbeat(len(errs) == 0)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// checkNode performs a single check of one node's status
+func (e *Engine) checkNode(n *nodeMonitor) error {
 	// Check node status
-	status, err := CheckNodeStatus(e.client, e.config)
+	status, err := CheckNodeStatus(n.client, e.config, n.config)
 	if err != nil {
+		e.recordDigestFailure(n)
+		if lostErr := e.checkFailed(n, err); lostErr != nil {
+			err = fmt.Errorf("%w; %v", err, lostErr)
+		}
 		return fmt.Errorf("[ERROR] failed to check node status: %w", err)
 	}
+	status.Node = n.name
+	status.Label = n.config.Label
+
+	if n.failedChecks > 0 {
+		if err := e.connectionRestored(n, status); err != nil {
+			e.log.Error(fmt.Sprintf("[%s] %v", n.name, err), "node", n.name, "error", err)
+		}
+	}
+
+	// A restart starts a new uptime period
+	previous := n.lastStatus
+	restart := restartReason(previous, status)
+	if restart != "" || n.firstSeen.IsZero() {
+		n.firstSeen = status.Timestamp
+	}
+	status.FirstSeen = n.firstSeen
+
+	e.checkStall(n, previous, status)
+	e.checkPeers(n, status)
+	e.checkBandwidth(n, status)
+	e.checkLatency(n, status)
+	e.checkAhead(n, status)
+	e.checkBlob(n, status)
+	e.checkCheckpoints(n, status)
+	e.checkStore(n, status)
+	e.checkProcess(n, status)
+	e.checkQueries(n, status)
 
 	// Update last status
-	e.lastStatus = status
+	e.mu.Lock()
+	n.lastStatus = status
+	e.mu.Unlock()
+
+	n.recent = append(n.recent, status)
+	if len(n.recent) > trendChecks {
+		n.recent = n.recent[len(n.recent)-trendChecks:]
+	}
+	e.recordDigest(n, status)
 
 	// Always print basic status in info mode
 	e.printInfoStatus(status)
+	if len(status.Errors) > 0 {
+		e.log.Warn(fmt.Sprintf("[%s] Partial check: %s", n.name, strings.Join(status.Errors, "; ")),
+			"node", n.name, "errors", status.Errors)
+	}
+	if e.debug {
+		e.printDebugStatus(status)
+	}
+
+	if restart != "" {
+		e.log.Warn(fmt.Sprintf("[%s] Node appears to have restarted: %s", n.name, restart), "node", n.name, "reason", restart)
+		if e.config.Alerts.Enabled {
+			if err := e.sendRestartAlert(status, restart); err != nil {
+				return fmt.Errorf("[ERROR] failed to send restart alert: %w", err)
+			}
+		}
+	}
+
+	if natChanged(e.config, previous, status) {
+		e.log.Warn(fmt.Sprintf("[%s] NAT status changed from %s to %s", n.name, previous.NATStatus, status.NATStatus),
+			"node", n.name, "previous_nat", previous.NATStatus, "nat", status.NATStatus)
+		if e.config.Alerts.Enabled {
+			if err := e.sendNATAlert(previous, status); err != nil {
+				return fmt.Errorf("[ERROR] failed to send NAT alert: %w", err)
+			}
+		}
+	}
+
+	// Track when the current unhealthy period started. On the first check
+	// a period restored from the alert state continues.
+	if previous == nil && status.Healthy {
+		n.unhealthySince = time.Time{}
+		n.unhealthyChecks = 0
+		n.episodeSeverity = 0
+		n.escalated = false
+		e.endEpisode(n)
+	}
+	if !status.Healthy && ((previous == nil && n.unhealthySince.IsZero()) || (previous != nil && previous.Healthy)) {
+		n.unhealthySince = status.Timestamp
+		n.unhealthyChecks = 0
+	}
+	if !status.Healthy {
+		n.unhealthyChecks++
+	}
+
+	// Reset cooldowns for categories that are healthy again
+	unhealthy := unhealthyCategories(status)
+	for category := range n.lastAlertSent {
+		if !slices.Contains(unhealthy, category) {
+			delete(n.lastAlertSent, category)
+		}
+	}
 
 	// Send alerts if needed
 	if !status.Healthy && e.config.Alerts.Enabled {
-		if err := e.sendAlerts(status); err != nil {
+		if err := e.sendAlerts(n, status); err != nil {
 			return fmt.Errorf("[ERROR] failed to send alerts: %w", err)
 		}
 	}
 
+	// A held back recovery is moot once the node is unhealthy again
+	if n.pendingRecovery != nil {
+		if !status.Healthy {
+			n.pendingRecovery = nil
+		} else if err := e.sendPendingRecovery(n); err != nil {
+			return fmt.Errorf("[ERROR] failed to send recovery alert: %w", err)
+		}
+	}
+
+	// Send a recovery notification when the node becomes healthy again
+	if status.Healthy && previous != nil && !previous.Healthy {
+		downtime := status.Timestamp.Sub(n.unhealthySince)
+		n.unhealthySince = time.Time{}
+		n.unhealthyChecks = 0
+
+		var err error
+		if e.config.Alerts.Enabled && e.config.Alerts.NotifyRecovery {
+			err = e.sendRecovery(n, previous, status, downtime)
+		}
+		n.episodeSeverity = 0
+		n.escalated = false
+		e.endEpisode(n)
+		if err != nil {
+			return fmt.Errorf("[ERROR] failed to send recovery alert: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkFailed counts a failed check and alerts that the node is
+// unreachable once alerts.unreachable_after_checks checks failed in a row,
+// again whenever the cooldown expires. A lost connection is reconnected,
+// backing off between attempts.
+func (e *Engine) checkFailed(n *nodeMonitor, cause error) error {
+	now := time.Now()
+
+	n.failedChecks++
+	if !n.connLost && n.failedChecks >= e.config.Alerts.UnreachableAfterChecks {
+		n.connLost = true
+		e.log.Warn(fmt.Sprintf("[%s] Node unreachable after %d failed checks: %v", n.name, n.failedChecks, cause),
+			"node", n.name, "failed_checks", n.failedChecks, "error", cause)
+	}
+
+	var alertErr error
+	if n.connLost && e.config.Alerts.Enabled && e.acknowledged(n) == "" && !e.inCooldown(n, alertCategoryRPC, alert.SeverityCritical, now) {
+		message := fmt.Sprintf("[%s] 🔌 Node unreachable\n\n", n.config.DisplayName())
+		message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(now))
+		message += fmt.Sprintf("Endpoint: %s\n", n.client.Endpoint())
+		message += fmt.Sprintf("Failed checks: %d\n", n.failedChecks)
+		message += fmt.Sprintf("Error: %v\n", cause)
+
+		silenced, err := e.send(alert.Alert{
+			Kind:       alert.KindProblem,
+			Severity:   alert.SeverityCritical,
+			Node:       n.name,
+			Categories: []string{alertCategoryRPC},
+			Message:    message,
+			Timestamp:  now,
+		})
+		if err != nil {
+			alertErr = fmt.Errorf("[ERROR] failed to send node unreachable alert: %w", err)
+		}
+		// A held back alert starts no cooldown, so it goes out on the first
+		// check after the maintenance
+		if !silenced {
+			n.lastAlertSent[alertCategoryRPC] = sentAlert{
+				Fingerprint: alertFingerprint(n.name, alertCategoryRPC, alert.SeverityCritical),
+				Severity:    alert.SeverityCritical,
+				SentAt:      now,
+			}
+			n.connAlerted = true
+			e.startEpisode(n)
+		}
+	}
+
+	if !rpc.IsConnectionError(cause) || now.Before(n.nextReconnect) {
+		return alertErr
+	}
+
+	n.reconnectAttempts++
+	delay := reconnectMinDelay
+	for i := 1; i < n.reconnectAttempts && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, reconnectMaxDelay)
+	n.nextReconnect = now.Add(delay)
+
+	if err := n.client.Reconnect(); err != nil {
+		e.log.Warn(fmt.Sprintf("[%s] Reconnect failed, retrying in %s: %v", n.name, delay, err), "node", n.name, "error", err)
+		return alertErr
+	}
+	e.log.Info(fmt.Sprintf("[%s] Reconnected RPC client (attempt %d)", n.name, n.reconnectAttempts), "node", n.name, "attempt", n.reconnectAttempts)
+
+	return alertErr
+}
+
+// connectionRestored resets the failure count after a successful check
+// and notifies that an unreachable node answers again
+func (e *Engine) connectionRestored(n *nodeMonitor, status *Status) error {
+	failedChecks, lost, alerted := n.failedChecks, n.connLost, n.connAlerted
+	if status.Healthy {
+		e.endEpisode(n)
+	}
+	n.failedChecks = 0
+	n.connLost = false
+	n.connAlerted = false
+	n.reconnectAttempts = 0
+	n.nextReconnect = time.Time{}
+	delete(n.lastAlertSent, alertCategoryRPC)
+	if !lost {
+		return nil
+	}
+	e.log.Info(fmt.Sprintf("[%s] Node reachable again after %d failed checks", n.name, failedChecks), "node", n.name, "failed_checks", failedChecks)
+
+	// Nobody was told about the outage, so there is nothing to resolve
+	if !e.config.Alerts.Enabled || !alerted {
+		return nil
+	}
+
+	message := fmt.Sprintf("[%s] 🔌 Node reachable again\n\n", n.config.DisplayName())
+	message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(status.Timestamp))
+	message += fmt.Sprintf("Endpoint: %s\n", n.client.Endpoint())
+	message += fmt.Sprintf("Failed checks: %d\n", failedChecks)
+
+	if _, err := e.send(alert.Alert{
+		Kind:       alert.KindRecovery,
+		Severity:   alert.SeverityCritical,
+		Node:       n.name,
+		Categories: []string{alertCategoryRPC},
+		Message:    message,
+		Timestamp:  status.Timestamp,
+		Status:     status,
+		Facts:      statusFacts(status),
+		Summary:    statusSummary(status),
+	}); err != nil {
+		return fmt.Errorf("[ERROR] failed to send node reachable alert: %w", err)
+	}
+
 	return nil
 }
 
+// checkStall marks sync unhealthy when the local height has not advanced
+// within the stall timeout while the node is behind the network. A caught
+// up node on a quiet network is not flagged.
+func (e *Engine) checkStall(n *nodeMonitor, previous, status *Status) {
+	if previous == nil || status.LocalHeight != previous.LocalHeight {
+		n.heightChangedAt = status.Timestamp
+		return
+	}
+
+	timeout := time.Duration(e.config.Thresholds.SyncStatus.StallTimeoutSeconds) * time.Second
+	stalledFor := status.Timestamp.Sub(n.heightChangedAt)
+	if timeout <= 0 || status.HeightDiff <= 0 || stalledFor < timeout {
+		return
+	}
+
+	status.StalledFor = int64(stalledFor.Seconds())
+	status.SyncHealthy = false
+	status.Healthy = false
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// checkBandwidth only reports low bandwidth once it lasted for
+// thresholds.bandwidth.low_checks consecutive checks, so a quiet moment
+// on the network does not flap the alert
+func (e *Engine) checkBandwidth(n *nodeMonitor, status *Status) {
+	// A failed query neither ends nor extends a low bandwidth period
+	if status.queryFailed(subsystemBandwidth) {
+		status.LowBandwidthChecks = n.lowBandwidthChecks
+		return
+	}
+	if status.BandwidthHealthy {
+		n.lowBandwidthChecks = 0
+		return
+	}
+
+	n.lowBandwidthChecks++
+	status.LowBandwidthChecks = n.lowBandwidthChecks
+	if n.lowBandwidthChecks >= e.config.Thresholds.Bandwidth.LowChecks {
+		return
+	}
+
+	status.BandwidthHealthy = true
+	status.Healthy = len(unhealthyCategories(status)) == 0
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// checkPeers applies thresholds.network.peer_smoothing_checks and
+// peer_hysteresis to the network health. The peer count is replaced by its
+// exponential moving average, and once it is below the minimum it must
+// reach the minimum plus the hysteresis to recover, so a peer count
+// hovering around the minimum does not flap.
+func (e *Engine) checkPeers(n *nodeMonitor, status *Status) {
+	network := e.config.Thresholds.Network
+	if network.PeerSmoothingChecks <= 1 && network.PeerHysteresis == 0 {
+		return
+	}
+	// A failed query neither moves the average nor ends a low peer period
+	if status.queryFailed(subsystemPeers) {
+		return
+	}
+
+	peers := float64(status.PeerCount)
+	if network.PeerSmoothingChecks > 1 {
+		if n.peerAverageSet {
+			n.peerAverage += 2 / float64(network.PeerSmoothingChecks+1) * (peers - n.peerAverage)
+		} else {
+			n.peerAverage, n.peerAverageSet = peers, true
+		}
+		peers = n.peerAverage
+		status.PeerCountSmoothed = peers
+	}
+
+	minPeers := float64(max(network.MinPeersHealthy, network.MinPeersWarning))
+	if n.lowPeers {
+		n.lowPeers = peers < minPeers+float64(network.PeerHysteresis)
+	} else {
+		n.lowPeers = peers < minPeers
+	}
+
+	status.NetHealthy = !n.lowPeers && !noInboundPeers(e.config, status)
+	status.Healthy = len(unhealthyCategories(status)) == 0
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// checkQueries only reports failed queries once checks were partial for
+// alerts.unreachable_after_checks checks in a row, as it does for checks
+// that failed entirely, so a single glitch does not raise an alert
+func (e *Engine) checkQueries(n *nodeMonitor, status *Status) {
+	if status.QueriesHealthy {
+		n.partialChecks = 0
+		return
+	}
+
+	n.partialChecks++
+	if n.partialChecks >= e.config.Alerts.UnreachableAfterChecks {
+		return
+	}
+
+	status.QueriesHealthy = true
+	status.Healthy = len(unhealthyCategories(status)) == 0
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// trendChecks is the number of checks whose readings alerts show as a trend
+const trendChecks = 3
+
+// latencyWindow is the number of checks the latency moving average covers
+const latencyWindow = 5
+
+// checkLatency marks the RPC endpoint slow when the average duration of
+// the last checks exceeds the threshold. A single slow check is not
+// flagged, a sustained slowdown often precedes an outage.
+func (e *Engine) checkLatency(n *nodeMonitor, status *Status) {
+	n.checkDurations = append(n.checkDurations, status.CheckDurationMs)
+	if len(n.checkDurations) > latencyWindow {
+		n.checkDurations = n.checkDurations[len(n.checkDurations)-latencyWindow:]
+	}
+
+	var total int64
+	for _, duration := range n.checkDurations {
+		total += duration
+	}
+	status.LatencyAvgMs = total / int64(len(n.checkDurations))
+
+	maxLatency := int64(e.config.Thresholds.RPC.MaxLatencyMs)
+	if maxLatency <= 0 || len(n.checkDurations) < latencyWindow || status.LatencyAvgMs <= maxLatency {
+		return
+	}
+
+	status.LatencyHealthy = false
+	status.Healthy = false
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// slowestCall returns the RPC call of the status that took longest
+func slowestCall(status *Status) (string, int64) {
+	var slowest string
+	var longest int64 = -1
+	for name, duration := range status.RPCDurationsMs {
+		if duration > longest || (duration == longest && name < slowest) {
+			slowest, longest = name, duration
+		}
+	}
+	return slowest, longest
+}
+
+// aheadNoteChecks is the number of consecutive checks with the local head
+// ahead of the network head after which it is logged
+const aheadNoteChecks = 5
+
+// checkAhead logs when the local head stays ahead of the network head. A
+// lag of a check or two is normal gossip delay; a persistent one suggests
+// the node's trusted peers are behind or misconfigured.
+func (e *Engine) checkAhead(n *nodeMonitor, status *Status) {
+	if status.HeightDiff >= 0 {
+		n.aheadChecks = 0
+		return
+	}
+
+	n.aheadChecks++
+	if n.aheadChecks == aheadNoteChecks {
+		e.log.Warn(fmt.Sprintf("[%s] Local height %d has been ahead of network height %d for %d checks, check the node's trusted peers",
+			n.name, status.LocalHeight, status.NetworkHeight, n.aheadChecks),
+			"node", n.name, "local_height", status.LocalHeight, "network_height", status.NetworkHeight, "checks", n.aheadChecks)
+	}
+}
+
+// checkBlob starts a test blob submission when the blob check is due and
+// applies the latest result to the status. Submissions cost gas, so they
+// run every interval rather than on every check, and they run in the
+// background so a slow inclusion does not hold up the node's checks.
+func (e *Engine) checkBlob(n *nodeMonitor, status *Status) {
+	cfg := e.config.Thresholds.Blob
+	if !cfg.Enabled {
+		return
+	}
+
+	interval := time.Duration(cfg.IntervalMinutes) * time.Minute
+	n.blobMu.Lock()
+	due := !n.blobRunning && (n.lastBlob == nil || status.Timestamp.Sub(n.lastBlob.CheckedAt) >= interval)
+	n.blobRunning = n.blobRunning || due
+	n.blobMu.Unlock()
+
+	if due {
+		maxSubmit := time.Duration(cfg.MaxSubmitSeconds) * time.Second
+		if e.once {
+			e.submitBlob(n, cfg.Namespace, maxSubmit, status.Timestamp)
+		} else {
+			go e.submitBlob(n, cfg.Namespace, maxSubmit, status.Timestamp)
+		}
+	}
+
+	n.blobMu.Lock()
+	last := n.lastBlob
+	n.blobMu.Unlock()
+
+	// The first submission is still waiting to be included
+	if last == nil {
+		return
+	}
+
+	status.Blob = last
+	status.BlobHealthy = last.Error == "" && last.SubmitSeconds <= float64(cfg.MaxSubmitSeconds)
+	status.Healthy = len(unhealthyCategories(status)) == 0
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// submitBlob submits a test blob and stores the result as the node's latest
+// blob check, picked up by the next check
+func (e *Engine) submitBlob(n *nodeMonitor, namespace string, maxSubmit time.Duration, checkedAt time.Time) {
+	data := []byte(fmt.Sprintf("celestia-watchtower %s %s", n.name, checkedAt.Format(time.RFC3339)))
+
+	// Wait past the threshold so slow submissions still report their latency
+	start := time.Now()
+	height, err := n.client.SubmitBlob([]byte(namespace), data, 2*maxSubmit)
+	check := &BlobCheck{
+		CheckedAt:     checkedAt,
+		SubmitSeconds: time.Since(start).Seconds(),
+		Height:        height,
+	}
+	if err != nil {
+		check.Error = err.Error()
+		e.log.Warn(fmt.Sprintf("[%s] Blob submission failed: %v", n.name, err), "node", n.name, "error", err)
+	} else {
+		e.log.Info(fmt.Sprintf("[%s] Blob included at height %d after %.1fs", n.name, height, check.SubmitSeconds),
+			"node", n.name, "height", height, "submit_seconds", check.SubmitSeconds)
+	}
+
+	n.blobMu.Lock()
+	n.lastBlob = check
+	n.blobRunning = false
+	n.blobMu.Unlock()
+}
+
+// checkCheckpoints verifies the node's headers at the trusted checkpoints
+// when the verification is due, on the first check and then every
+// checkpoint_interval_minutes, and applies the latest result to the
+// status. Any mismatch makes the chain unhealthy.
+func (e *Engine) checkCheckpoints(n *nodeMonitor, status *Status) {
+	if len(n.config.Checkpoints) == 0 {
+		return
+	}
+
+	interval := time.Duration(n.config.CheckpointIntervalMinutes) * time.Minute
+	if n.lastCheckpoint == nil || status.Timestamp.Sub(n.lastCheckpoint.CheckedAt) >= interval {
+		n.lastCheckpoint = e.verifyCheckpoints(n, status)
+	}
+
+	status.Checkpoint = n.lastCheckpoint
+	if len(n.lastCheckpoint.Mismatches) > 0 {
+		status.ChainHealthy = false
+		status.Healthy = false
+		status.Severity = statusSeverity(e.config, status)
+	}
+}
+
+// verifyCheckpoints compares the node's header hash at each trusted
+// checkpoint it has reached. Headers that cannot be fetched, such as ones
+// a light node pruned, are reported without failing the check.
+func (e *Engine) verifyCheckpoints(n *nodeMonitor, status *Status) *CheckpointCheck {
+	check := &CheckpointCheck{CheckedAt: status.Timestamp}
+
+	var errs []string
+	for _, text := range n.config.Checkpoints {
+		checkpoint, err := config.ParseCheckpoint(text)
+		if err != nil || checkpoint.Height > status.LocalHeight {
+			continue
+		}
+
+		hash, err := n.client.GetHeaderHashAtHeight(checkpoint.Height)
+		switch {
+		case err != nil:
+			errs = append(errs, err.Error())
+		case !strings.EqualFold(hash, checkpoint.Hash):
+			check.Mismatches = append(check.Mismatches, CheckpointMismatch{Height: checkpoint.Height, Expected: checkpoint.Hash, Hash: hash})
+			e.log.Error(fmt.Sprintf("[%s] Header hash at height %d is %s, the trusted checkpoint is %s", n.name, checkpoint.Height, hash, checkpoint.Hash),
+				"node", n.name, "height", checkpoint.Height, "hash", hash, "expected_hash", checkpoint.Hash)
+		default:
+			check.Verified++
+		}
+	}
+
+	if len(errs) > 0 {
+		check.Error = strings.Join(errs, "; ")
+		e.log.Warn(fmt.Sprintf("[%s] Checkpoint verification incomplete: %s", n.name, check.Error), "node", n.name, "error", check.Error)
+	}
+	return check
+}
+
 // formatDataSize formats a byte value into the most appropriate unit
 // Returns the converted value and the unit string
 func formatDataSize(bytes float64) (float64, string) {
@@ -151,6 +1147,12 @@ func formatDataSize(bytes float64) (float64, string) {
     return bytes, "B"
 }
 
+// formatRate formats a rate in bytes per second with a readable unit
+func formatRate(bytesPerSecond float64) string {
+	rate, unit := formatDataSize(bytesPerSecond)
+	return fmt.Sprintf("%.2f %s/s", rate, unit)
+}
+
 // formatBandwidth formats bandwidth metrics consistently
 func formatBandwidth(status *Status) (inRate, outRate float64, inTotal, inUnit, outTotal, outUnit string) {
     // Convert rates to KB/s
@@ -166,17 +1168,15 @@ func formatBandwidth(status *Status) (inRate, outRate float64, inTotal, inUnit,
 
 // printInfoStatus prints basic status information in info mode
 func (e *Engine) printInfoStatus(status *Status) {
-	timestamp := status.Timestamp.Format("2006-01-02 15:04:05")
+	timestamp := e.config.FormatTime(status.Timestamp)
 	
 	// Health indicator
-	healthStatus := "[OK] HEALTHY"
-	if !status.Healthy {
-		healthStatus = "[!!] UNHEALTHY"
-	}
+	healthStatus := status.ColoredIndicator(e.color)
 	
 	inRate, outRate, inTotal, inUnit, outTotal, outUnit := formatBandwidth(status)
 	
-	fmt.Printf("[INFO] [%s] Status: %s | Height: %d/%d | Peers: %d | NAT: %s | In: %.1f KB/s (%s %s) | Out: %.1f KB/s (%s %s)\n", 
+	message := fmt.Sprintf("[%s] [%s] Status: %s | Height: %d/%d | Peers: %d | NAT: %s | In: %.1f KB/s (%s %s) | Out: %.1f KB/s (%s %s)",
+		status.DisplayName(),
 		timestamp, 
 		healthStatus, 
 		status.LocalHeight, 
@@ -185,38 +1185,834 @@ func (e *Engine) printInfoStatus(status *Status) {
 		status.NATStatus,
 		inRate, inTotal, inUnit,
 		outRate, outTotal, outUnit)
+	if status.StoreSizeBytes > 0 {
+		size, unit := formatDataSize(float64(status.StoreSizeBytes))
+		message += fmt.Sprintf(" | Store: %.2f %s", size, unit)
+		if status.StoreGrowthPerDay != 0 {
+			message += fmt.Sprintf(" (%s)", formatGrowth(status.StoreGrowthPerDay))
+		}
+	}
+	if status.NodeType != "" {
+		message += fmt.Sprintf(" | Node: %s %s", status.NodeType, status.APIVersion)
+	}
+
+	e.log.Info(message, statusAttrs(status)...)
+}
+
+// statusAttrs returns the structured log fields describing a status
+func statusAttrs(status *Status) []any {
+	return []any{
+		"node", status.Node,
+		"timestamp", status.Timestamp,
+		"healthy", status.Healthy,
+		"severity", status.Severity,
+		"local_height", status.LocalHeight,
+		"network_height", status.NetworkHeight,
+		"height_diff", status.HeightDiff,
+		"peers", status.PeerCount,
+		"nat", status.NATStatus,
+		"rate_in", status.Bandwidth.RateIn,
+		"rate_out", status.Bandwidth.RateOut,
+		"total_in", status.Bandwidth.TotalIn,
+		"total_out", status.Bandwidth.TotalOut,
+		"node_type", status.NodeType,
+		"api_version", status.APIVersion,
+		"first_seen", status.FirstSeen,
+		"store_size_bytes", status.StoreSizeBytes,
+		"store_growth_bytes_per_day", status.StoreGrowthPerDay,
+	}
+}
+
+// printDebugStatus prints detailed status information in debug mode
+func (e *Engine) printDebugStatus(status *Status) {
+	e.log.Debug(fmt.Sprintf("[%s] Sync: local=%d network=%d diff=%d stalled=%ds head_age=%ds healthy=%s",
+		status.Node, status.LocalHeight, status.NetworkHeight, status.HeightDiff, status.StalledFor, status.NetworkHeadAge,
+		e.healthyFlag(status.SyncHealthy)),
+		"node", status.Node, "local_height", status.LocalHeight, "network_height", status.NetworkHeight,
+		"height_diff", status.HeightDiff, "stalled_for_seconds", status.StalledFor, "network_head_age_seconds", status.NetworkHeadAge,
+		"sync_healthy", status.SyncHealthy)
+	e.log.Debug(fmt.Sprintf("[%s] Network: peers=%d average=%.1f inbound=%d outbound=%d nat=%s healthy=%s",
+		status.Node, status.PeerCount, peerLevel(status), status.PeerDetails.Inbound, status.PeerDetails.Outbound, status.NATStatus, e.healthyFlag(status.NetHealthy)),
+		"node", status.Node, "peers", status.PeerCount, "peers_average", peerLevel(status), "inbound_peers", status.PeerDetails.Inbound,
+		"outbound_peers", status.PeerDetails.Outbound, "nat", status.NATStatus, "net_healthy", status.NetHealthy)
+	slowest, slowestMs := slowestCall(status)
+	e.log.Debug(fmt.Sprintf("[%s] RPC: check=%dms avg=%dms slowest=%s (%dms) healthy=%s",
+		status.Node, status.CheckDurationMs, status.LatencyAvgMs, slowest, slowestMs, e.healthyFlag(status.LatencyHealthy)),
+		"node", status.Node, "check_duration_ms", status.CheckDurationMs, "latency_avg_ms", status.LatencyAvgMs,
+		"rpc_durations_ms", status.RPCDurationsMs, "latency_healthy", status.LatencyHealthy)
+	e.log.Debug(fmt.Sprintf("[%s] Bandwidth: total_in=%d total_out=%d rate_in=%.2f rate_out=%.2f healthy=%s",
+		status.Node, status.Bandwidth.TotalIn, status.Bandwidth.TotalOut, status.Bandwidth.RateIn, status.Bandwidth.RateOut,
+		e.healthyFlag(status.BandwidthHealthy)),
+		"node", status.Node, "total_in", status.Bandwidth.TotalIn, "total_out", status.Bandwidth.TotalOut,
+		"rate_in", status.Bandwidth.RateIn, "rate_out", status.Bandwidth.RateOut, "bandwidth_healthy", status.BandwidthHealthy)
+	if status.Sampling.Available {
+		e.log.Debug(fmt.Sprintf("[%s] Sampling: sampled=%d catchup=%d network=%d behind=%d done=%v running=%v healthy=%s",
+			status.Node, status.Sampling.SampledHeight, status.Sampling.CatchupHead, status.Sampling.NetworkHead,
+			status.Sampling.Behind, status.Sampling.CatchUpDone, status.Sampling.IsRunning, e.healthyFlag(status.SamplingHealthy)),
+			"node", status.Node, "sampled_height", status.Sampling.SampledHeight, "catchup_head", status.Sampling.CatchupHead,
+			"das_network_head", status.Sampling.NetworkHead, "sampling_behind", status.Sampling.Behind,
+			"catch_up_done", status.Sampling.CatchUpDone, "das_running", status.Sampling.IsRunning,
+			"sampling_healthy", status.SamplingHealthy)
+	}
+	if status.Resources.Available {
+		e.log.Debug(fmt.Sprintf("[%s] Resources: memory=%d conns=%d streams=%d fds=%d healthy=%s",
+			status.Node, status.Resources.MemoryBytes, status.Resources.Conns, status.Resources.Streams,
+			status.Resources.FDs, e.healthyFlag(status.ResourcesHealthy)),
+			"node", status.Node, "memory_bytes", status.Resources.MemoryBytes, "conns", status.Resources.Conns,
+			"streams", status.Resources.Streams, "fds", status.Resources.FDs, "resources_healthy", status.ResourcesHealthy)
+	}
+	if process := status.Process; process != nil {
+		e.log.Debug(fmt.Sprintf("[%s] Process: pid=%d cpu=%.1f%% rss=%d healthy=%s",
+			status.Node, process.PID, process.CPUPercent, process.RSSBytes, e.healthyFlag(status.ProcessHealthy)),
+			"node", status.Node, "process_pid", process.PID, "process_cpu_percent", process.CPUPercent,
+			"process_rss_bytes", process.RSSBytes, "process_healthy", status.ProcessHealthy)
+	}
+	if status.Blob != nil {
+		e.log.Debug(fmt.Sprintf("[%s] Blob: submit=%.1fs height=%d error=%q healthy=%s",
+			status.Node, status.Blob.SubmitSeconds, status.Blob.Height, status.Blob.Error, e.healthyFlag(status.BlobHealthy)),
+			"node", status.Node, "blob_submit_seconds", status.Blob.SubmitSeconds, "blob_height", status.Blob.Height,
+			"blob_error", status.Blob.Error, "blob_healthy", status.BlobHealthy)
+	}
+	if check := status.Checkpoint; check != nil {
+		e.log.Debug(fmt.Sprintf("[%s] Checkpoints: verified=%d mismatched=%d error=%q healthy=%s",
+			status.Node, check.Verified, len(check.Mismatches), check.Error, e.healthyFlag(len(check.Mismatches) == 0)),
+			"node", status.Node, "checkpoints_verified", check.Verified, "checkpoints_mismatched", len(check.Mismatches),
+			"checkpoint_error", check.Error)
+	}
+	if status.DiskFreeBytes > 0 || status.DiskPercentUsed > 0 {
+		e.log.Debug(fmt.Sprintf("[%s] Disk: free=%d used=%.1f%% healthy=%s",
+			status.Node, status.DiskFreeBytes, status.DiskPercentUsed, e.healthyFlag(status.DiskHealthy)),
+			"node", status.Node, "disk_free_bytes", status.DiskFreeBytes, "disk_percent_used", status.DiskPercentUsed,
+			"disk_healthy", status.DiskHealthy)
+	}
+}
+
+// healthyFlag formats a healthy= value of the debug output, colored green
+// or red when color is on
+func (e *Engine) healthyFlag(healthy bool) string {
+	if healthy {
+		return logging.Paint(e.color, logging.ColorGreen, "true")
+	}
+	return logging.Paint(e.color, logging.ColorRed, "false")
+}
+
+// unhealthyCategories returns the alert categories that are unhealthy in the status
+func unhealthyCategories(status *Status) []string {
+	var categories []string
+	if !status.ChainHealthy {
+		categories = append(categories, alertCategoryChain)
+	}
+	if !status.SyncHealthy {
+		categories = append(categories, alertCategorySync)
+	}
+	if !status.NetHealthy {
+		categories = append(categories, alertCategoryNetwork)
+	}
+	if !status.BandwidthHealthy {
+		categories = append(categories, alertCategoryBandwidth)
+	}
+	if !status.SamplingHealthy {
+		categories = append(categories, alertCategorySampling)
+	}
+	if !status.DiskHealthy {
+		categories = append(categories, alertCategoryDisk)
+	}
+	if !status.StoreHealthy {
+		categories = append(categories, alertCategoryStore)
+	}
+	if !status.ResourcesHealthy {
+		categories = append(categories, alertCategoryResources)
+	}
+	if !status.ProcessHealthy {
+		categories = append(categories, alertCategoryProcess)
+	}
+	if !status.BlobHealthy {
+		categories = append(categories, alertCategoryBlob)
+	}
+	if !status.LatencyHealthy {
+		categories = append(categories, alertCategoryLatency)
+	}
+	if !status.QueriesHealthy {
+		categories = append(categories, alertCategoryQueries)
+	}
+	return categories
+}
+
+// inCooldown reports whether the same alert of the given category was sent
+// recently for the node. An alert whose severity worsened is not held back.
+func (e *Engine) inCooldown(n *nodeMonitor, category string, severity alert.Severity, now time.Time) bool {
+	lastSent, ok := n.lastAlertSent[category]
+	if !ok || severity > lastSent.Severity {
+		return false
+	}
+
+	cooldown := time.Duration(e.config.Alerts.AlertCooldownMinutes) * time.Minute
+	return now.Sub(lastSent.SentAt) < cooldown
+}
+
+// shouldEscalate reports whether the node has been unhealthy long enough to escalate
+func (e *Engine) shouldEscalate(n *nodeMonitor, now time.Time) bool {
+	after := time.Duration(e.config.Alerts.EscalateAfterMinutes) * time.Minute
+	checks := e.config.Alerts.EscalateAfterChecks
+	return (after > 0 && now.Sub(n.unhealthySince) >= after) || (checks > 0 && n.unhealthyChecks >= checks)
 }
 
 // sendAlerts sends alerts to all configured channels
-func (e *Engine) sendAlerts(status *Status) error {
+func (e *Engine) sendAlerts(n *nodeMonitor, status *Status) error {
+	// Escalate as soon as the threshold is crossed, regardless of cooldowns
+	escalated := e.shouldEscalate(n, status.Timestamp)
+	escalating := escalated && !n.escalated
+
+	// Only include categories that are not in cooldown. Once the problem
+	// is acknowledged only categories that were not alerted yet go out.
+	acknowledgedBy := e.acknowledged(n)
+	var due, suppressed, acknowledged []string
+	for _, category := range unhealthyCategories(status) {
+		_, alerted := n.lastAlertSent[category]
+		switch {
+		case acknowledgedBy != "" && alerted:
+			acknowledged = append(acknowledged, category)
+		case escalating || !e.inCooldown(n, category, categorySeverity(e.config, category, status), status.Timestamp):
+			due = append(due, category)
+		default:
+			suppressed = append(suppressed, category)
+		}
+	}
+
+	if len(suppressed) > 0 {
+		e.log.Info(fmt.Sprintf("[%s] Alert suppressed for %s: still in cooldown", n.name, strings.Join(suppressed, ", ")),
+			"node", n.name, "categories", suppressed)
+	}
+	if len(acknowledged) > 0 {
+		e.log.Info(fmt.Sprintf("[%s] Alert suppressed for %s: acknowledged by %s", n.name, strings.Join(acknowledged, ", "), acknowledgedBy),
+			"node", n.name, "categories", acknowledged, "acknowledged_by", acknowledgedBy)
+	}
+	if len(due) == 0 {
+		if acknowledgedBy == "" && e.reminderDue(n, status.Timestamp) {
+			return e.sendReminder(n, status)
+		}
+		return nil
+	}
+
+	// Describe each unhealthy category as a separate issue
+	issues := make([]alert.Issue, 0, len(due))
+	for _, category := range due {
+		issues = append(issues, e.issue(n, category, status))
+	}
+
 	// Prepare alert message
-	message := fmt.Sprintf("⚠️ Celestia Node Alert ⚠️\n\n")
-	
+	message := fmt.Sprintf("[%s] ⚠️ Celestia Node Alert ⚠️\n", status.DisplayName())
+	if escalated {
+		message = fmt.Sprintf("[%s] 🚨 ESCALATED: Celestia Node Unhealthy 🚨\n", status.DisplayName())
+	}
+	message += alert.IssueSummary(issues) + "\n\n"
+
 	// Add timestamp
-	message += fmt.Sprintf("Time: %s\n\n", status.Timestamp.Format("2006-01-02 15:04:05"))
-	
-	// Add sync status if unhealthy
-	if !status.SyncHealthy {
-		message += fmt.Sprintf("❌ Sync Issue: Node is %d blocks behind the network\n", status.HeightDiff)
-		message += fmt.Sprintf("   Local Height: %d, Network Height: %d\n\n", status.LocalHeight, status.NetworkHeight)
+	summary := trendSummary(n, status)
+	message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(status.Timestamp))
+	if escalated {
+		message += fmt.Sprintf("Unhealthy for: %s (%d consecutive checks)\n", status.Timestamp.Sub(n.unhealthySince).Round(time.Second), n.unhealthyChecks)
 	}
-	
-	// Add network status if unhealthy
-	if !status.NetHealthy {
-		message += fmt.Sprintf("❌ Network Issue: Node has only %d peers (min: %d)\n", 
-			status.PeerCount, e.config.Thresholds.Network.MinPeersHealthy)
-		message += fmt.Sprintf("   NAT Status: %s\n\n", status.NATStatus)
+	if trend := summary.Trend(); trend != "" {
+		message += trend + "\n"
 	}
-	
+	message += "\n"
+
+	// Add a section for each issue
+	for _, issue := range issues {
+		message += issue.Text()
+	}
+
+	// The alert is as severe as its worst issue, and sustained problems are critical
+	severity := alert.SeverityWarning
+	for _, issue := range issues {
+		severity = max(severity, issue.Severity)
+	}
+	if escalated {
+		severity = alert.SeverityCritical
+	}
+	if escalating {
+		e.log.Warn(fmt.Sprintf("[%s] Escalating: unhealthy for %s (%d consecutive checks)", n.name, status.Timestamp.Sub(n.unhealthySince).Round(time.Second), n.unhealthyChecks),
+			"node", n.name, "unhealthy_since", n.unhealthySince, "unhealthy_checks", n.unhealthyChecks)
+	}
+
 	// Send alert
-	if err := e.alerter.SendAlert(message); err != nil {
+	silenced, err := e.send(alert.Alert{
+		Kind:       alert.KindProblem,
+		Severity:   severity,
+		Node:       status.Node,
+		Categories: due,
+		Issues:     issues,
+		Message:    message,
+		Timestamp:  status.Timestamp,
+		Status:     status,
+		Facts:      statusFacts(status),
+		Summary:    summary,
+		Escalated:  escalated,
+	})
+	if err != nil {
 		return fmt.Errorf("[ERROR] failed to send alert: %w", err)
 	}
+
+	// A held back alert starts no cooldown, so it goes out on the first
+	// check after the maintenance if the node is still unhealthy
+	if silenced {
+		return nil
+	}
+
+	// Start cooldown for the categories that were sent
+	for _, issue := range issues {
+		n.lastAlertSent[issue.Category] = sentAlert{
+			Fingerprint: alertFingerprint(n.name, issue.Category, issue.Severity),
+			Severity:    issue.Severity,
+			SentAt:      status.Timestamp,
+		}
+	}
+	n.episodeSeverity = max(n.episodeSeverity, severity)
+	n.escalated = n.escalated || escalated
+	e.startEpisode(n)
 	
 	return nil
 }
 
-// logError logs an error message
-func logError(format string, args ...interface{}) {
-	fmt.Printf("[ERROR] %s\n", fmt.Sprintf(format, args...))
+// reminderDue reports whether the node's alerted problem should be
+// brought up again: nothing went out for it within
+// alerts.reminder_interval_minutes
+func (e *Engine) reminderDue(n *nodeMonitor, now time.Time) bool {
+	interval := time.Duration(e.config.Alerts.ReminderIntervalMinutes) * time.Minute
+	if interval <= 0 || !e.alertingNode(n) {
+		return false
+	}
+
+	lastSent := n.lastReminder
+	for _, sent := range n.lastAlertSent {
+		if sent.SentAt.After(lastSent) {
+			lastSent = sent.SentAt
+		}
+	}
+	return now.Sub(lastSent) >= interval
+}
+
+// sendReminder reminds all configured channels that the node is still
+// unhealthy, with how long it has been and the latest numbers
+func (e *Engine) sendReminder(n *nodeMonitor, status *Status) error {
+	categories := unhealthyCategories(status)
+	issues := make([]alert.Issue, 0, len(categories))
+	severity := alert.SeverityWarning
+	for _, category := range categories {
+		issue := e.issue(n, category, status)
+		issues = append(issues, issue)
+		severity = max(severity, issue.Severity)
+	}
+	if n.escalated {
+		severity = alert.SeverityCritical
+	}
+
+	// Whole minutes, as in "3h0m" rather than "3h0m0s"
+	unhealthyFor := strings.TrimSuffix(status.Timestamp.Sub(n.unhealthySince).Round(time.Minute).String(), "0s")
+	message := fmt.Sprintf("[%s] ⏰ Reminder: still unhealthy, %s and counting\n", status.DisplayName(), unhealthyFor)
+	message += alert.IssueSummary(issues) + "\n\n"
+	message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(status.Timestamp))
+	message += fmt.Sprintf("Unhealthy since: %s (%d consecutive checks)\n", e.config.FormatTime(n.unhealthySince), n.unhealthyChecks)
+	summary := trendSummary(n, status)
+	if trend := summary.Trend(); trend != "" {
+		message += trend + "\n"
+	}
+	message += "\n"
+	for _, issue := range issues {
+		message += issue.Text()
+	}
+
+	silenced, err := e.send(alert.Alert{
+		Kind:       alert.KindProblem,
+		Severity:   severity,
+		Node:       status.Node,
+		Categories: categories,
+		Issues:     issues,
+		Message:    message,
+		Timestamp:  status.Timestamp,
+		Status:     status,
+		Facts:      statusFacts(status),
+		Summary:    summary,
+		Escalated:  n.escalated,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] failed to send reminder: %w", err)
+	}
+
+	// A held back reminder goes out on the first check after the maintenance
+	if !silenced {
+		e.log.Info(fmt.Sprintf("[%s] Reminder sent: unhealthy for %s", n.name, unhealthyFor), "node", n.name, "unhealthy_since", n.unhealthySince)
+		n.lastReminder = status.Timestamp
+	}
+	return nil
+}
+
+// categorySeverity grades an unhealthy category by how far it is past its threshold
+func categorySeverity(cfg *config.Config, category string, status *Status) alert.Severity {
+	thresholds := cfg.Thresholds
+
+	switch category {
+	case alertCategoryChain:
+		// Every other check is meaningless on the wrong chain
+		return alert.SeverityCritical
+	case alertCategorySync:
+		// A frozen network head means the node no longer follows the chain
+		if status.HeightDiff > int64(thresholds.SyncStatus.BlocksBehindCritical) || headStale(cfg, status) {
+			return alert.SeverityCritical
+		}
+	case alertCategoryNetwork:
+		if status.PeerCount == 0 || peerLevel(status) < float64(thresholds.Network.MinPeersHealthy) {
+			return alert.SeverityCritical
+		}
+	case alertCategoryBandwidth:
+		// No traffic at all in a checked direction means the node is cut off
+		if (thresholds.Bandwidth.MinRateInBytes > 0 && status.Bandwidth.RateIn == 0) ||
+			(thresholds.Bandwidth.MinRateOutBytes > 0 && status.Bandwidth.RateOut == 0) {
+			return alert.SeverityCritical
+		}
+	case alertCategorySampling:
+		if !status.Sampling.IsRunning || status.Sampling.Behind > int64(samplingCriticalBehind(cfg)) {
+			return alert.SeverityCritical
+		}
+	case alertCategoryDisk:
+		if 100-status.DiskPercentUsed < thresholds.Disk.MinFreePercent/2 {
+			return alert.SeverityCritical
+		}
+	case alertCategoryResources:
+		// Memory growth is close to an OOM kill well before it doubles
+		if status.Resources.MemoryBytes > int64(thresholds.Resources.MaxMemoryMB)*1024*1024*3/2 {
+			return alert.SeverityCritical
+		}
+	case alertCategoryProcess:
+		// Like the libp2p memory, resident memory well above the limit is close to an OOM kill
+		if status.Process != nil && thresholds.Process.MaxMemoryMB > 0 &&
+			status.Process.RSSBytes > int64(thresholds.Process.MaxMemoryMB)*1024*1024*3/2 {
+			return alert.SeverityCritical
+		}
+	case alertCategoryLatency:
+		if status.LatencyAvgMs > 2*int64(thresholds.RPC.MaxLatencyMs) {
+			return alert.SeverityCritical
+		}
+	case alertCategoryBlob:
+		// A failed submission means the node cannot post blobs at all
+		if status.Blob != nil && status.Blob.Error != "" {
+			return alert.SeverityCritical
+		}
+	}
+
+	return alert.SeverityWarning
+}
+
+// issue describes an unhealthy category in an alert message
+func (e *Engine) issue(n *nodeMonitor, category string, status *Status) alert.Issue {
+	issue := alert.Issue{Category: category, Severity: categorySeverity(e.config, category, status)}
+
+	switch category {
+	case alertCategoryChain:
+		issue.Name = "Chain Issue"
+		if check := status.Checkpoint; check != nil && len(check.Mismatches) > 0 {
+			mismatch := check.Mismatches[0]
+			issue.Summary = "checkpoint mismatch"
+			issue.Detail = fmt.Sprintf("Header hash at height %d differs from the trusted checkpoint", mismatch.Height)
+			issue.Context = []string{
+				fmt.Sprintf("Expected: %s", mismatch.Expected),
+				fmt.Sprintf("Node: %s", mismatch.Hash),
+			}
+			if len(check.Mismatches) > 1 {
+				issue.Context = append(issue.Context, fmt.Sprintf("%d more checkpoints differ", len(check.Mismatches)-1))
+			}
+			issue.Context = append(issue.Context, "The node may be on a fork or its header store corrupted")
+			break
+		}
+		if expected := n.config.ExpectedChainID; expected != "" && status.ChainID != expected {
+			issue.Summary = "wrong chain"
+			issue.Detail = fmt.Sprintf("Node is on chain %q, expected %q", status.ChainID, expected)
+			issue.Context = []string{"Check that the watchtower points at the right node"}
+			break
+		}
+		issue.Summary = "chain reset"
+		issue.Detail = fmt.Sprintf("Local height %d is below the expected minimum %d", status.LocalHeight, n.config.ExpectedMinHeight)
+		issue.Context = []string{"The node may have been reset"}
+	case alertCategorySync:
+		issue.Name = "Sync Issue"
+		issue.Summary = "sync lag"
+		issue.Detail = fmt.Sprintf("Node is %d blocks behind the network", status.HeightDiff)
+		if status.StalledFor > 0 {
+			issue.Summary = "sync stall"
+			issue.Detail = fmt.Sprintf("Local height stuck at %d for %d seconds", status.LocalHeight, status.StalledFor)
+		}
+		if headStale(e.config, status) {
+			issue.Summary = "stale head"
+			issue.Detail = fmt.Sprintf("Newest network header is %d seconds old (max: %d)",
+				status.NetworkHeadAge, e.config.Thresholds.SyncStatus.MaxHeadAgeSeconds)
+		}
+		issue.Context = []string{fmt.Sprintf("Local Height: %d, Network Height: %d", status.LocalHeight, status.NetworkHeight)}
+	case alertCategoryNetwork:
+		issue.Name = "Network Issue"
+		network := e.config.Thresholds.Network
+		level, limit := peerLevel(status), max(network.MinPeersHealthy, network.MinPeersWarning)
+		minPeers := network.MinPeersHealthy
+		if level >= float64(minPeers) {
+			minPeers = network.MinPeersWarning
+		}
+		if level >= float64(limit) && noInboundPeers(e.config, status) {
+			issue.Summary = "no inbound peers"
+			issue.Detail = fmt.Sprintf("None of the node's %d peers connected to it (possible NAT or firewall problem)", status.PeerCount)
+			issue.Context = []string{fmt.Sprintf("Outbound Peers: %d, NAT Status: %s", status.PeerDetails.Outbound, status.NATStatus)}
+			break
+		}
+		issue.Summary = "low peers"
+		peers := fmt.Sprintf("%d peers", status.PeerCount)
+		if status.PeerCountSmoothed > 0 {
+			peers += fmt.Sprintf(", average %.1f", status.PeerCountSmoothed)
+		}
+		if level >= float64(limit) {
+			// Held below the minimum by the hysteresis
+			issue.Detail = fmt.Sprintf("Node has only %s, recovers at %d", peers, limit+network.PeerHysteresis)
+		} else {
+			issue.Detail = fmt.Sprintf("Node has only %s (min: %d)", peers, minPeers)
+		}
+		issue.Context = []string{fmt.Sprintf("NAT Status: %s", status.NATStatus)}
+	case alertCategoryBandwidth:
+		minIn, minOut := e.config.Thresholds.Bandwidth.MinRateInBytes, e.config.Thresholds.Bandwidth.MinRateOutBytes
+		var low []string
+		if minIn > 0 && status.Bandwidth.RateIn < float64(minIn) {
+			low = append(low, fmt.Sprintf("In: %s (min: %s)", formatRate(status.Bandwidth.RateIn), formatRate(float64(minIn))))
+		}
+		if minOut > 0 && status.Bandwidth.RateOut < float64(minOut) {
+			low = append(low, fmt.Sprintf("Out: %s (min: %s)", formatRate(status.Bandwidth.RateOut), formatRate(float64(minOut))))
+		}
+		issue.Name = "Bandwidth Issue"
+		issue.Summary = "low bandwidth"
+		issue.Detail = fmt.Sprintf("Bandwidth below the minimum for %d consecutive checks, %s", status.LowBandwidthChecks, strings.Join(low, ", "))
+		issue.Context = []string{fmt.Sprintf("Peers: %d, NAT Status: %s", status.PeerCount, status.NATStatus)}
+		if minIn > 0 && status.Bandwidth.RateIn < float64(minIn) {
+			issue.Context = append(issue.Context, "The node may have stopped receiving gossip")
+		}
+	case alertCategorySampling:
+		issue.Name = "Sampling Issue"
+		issue.Summary = "sampling lag"
+		issue.Detail = fmt.Sprintf("DASer is %d headers behind the network (max: %d)",
+			status.Sampling.Behind, samplingMaxBehind(e.config))
+		if !status.Sampling.IsRunning {
+			issue.Summary = "sampling stopped"
+			issue.Detail = "DASer is not running"
+		}
+		issue.Context = []string{fmt.Sprintf("Sampled Height: %d, Network Head: %d", status.Sampling.SampledHeight, status.Sampling.NetworkHead)}
+	case alertCategoryDisk:
+		free, unit := formatDataSize(float64(status.DiskFreeBytes))
+		issue.Name = "Disk Issue"
+		issue.Summary = "low disk space"
+		issue.Detail = fmt.Sprintf("Only %.1f%% free on the data directory (min: %.1f%%)",
+			100-status.DiskPercentUsed, e.config.Thresholds.Disk.MinFreePercent)
+		issue.Context = []string{fmt.Sprintf("Free Space: %.2f %s", free, unit)}
+	case alertCategoryStore:
+		size, unit := formatDataSize(float64(status.StoreSizeBytes))
+		issue.Name = "Store Size Issue"
+		issue.Summary = "large store"
+		issue.Detail = fmt.Sprintf("Node store is %.2f %s (max: %d GB)", size, unit, e.config.Thresholds.Disk.MaxStoreSizeGB)
+		if status.StoreGrowthPerDay != 0 {
+			issue.Context = []string{fmt.Sprintf("Growth: %s", formatGrowth(status.StoreGrowthPerDay))}
+		}
+	case alertCategoryResources:
+		memory, unit := formatDataSize(float64(status.Resources.MemoryBytes))
+		issue.Name = "Memory Issue"
+		issue.Summary = "high memory"
+		issue.Detail = fmt.Sprintf("Node libp2p stack uses %.2f %s (max: %d MB)",
+			memory, unit, e.config.Thresholds.Resources.MaxMemoryMB)
+		issue.Context = []string{fmt.Sprintf("Connections: %d, Streams: %d, File Descriptors: %d",
+			status.Resources.Conns, status.Resources.Streams, status.Resources.FDs)}
+	case alertCategoryProcess:
+		thresholds := e.config.Thresholds.Process
+		summary, high := processLimits(thresholds.MaxCPUPercent, thresholds.MaxMemoryMB, status.Process)
+		memory, unit := formatDataSize(float64(status.Process.RSSBytes))
+		issue.Name = "Process Issue"
+		issue.Summary = summary
+		issue.Detail = "Node process is above its limits, " + strings.Join(high, ", ")
+		issue.Context = []string{fmt.Sprintf("PID: %d, CPU: %.1f%%, Memory: %.2f %s",
+			status.Process.PID, status.Process.CPUPercent, memory, unit)}
+	case alertCategoryLatency:
+		slowest, slowestMs := slowestCall(status)
+		issue.Name = "RPC Latency Issue"
+		issue.Summary = "slow RPC"
+		issue.Detail = fmt.Sprintf("Checks took %d ms on average over the last %d checks (max: %d ms)",
+			status.LatencyAvgMs, latencyWindow, e.config.Thresholds.RPC.MaxLatencyMs)
+		issue.Context = []string{fmt.Sprintf("Last Check: %d ms, Slowest Call: %s (%d ms)", status.CheckDurationMs, slowest, slowestMs)}
+	case alertCategoryQueries:
+		issue.Name = "Query Issue"
+		issue.Summary = "partial check"
+		issue.Detail = fmt.Sprintf("Could not query %s for %d checks in a row", strings.Join(status.failedSubsystems(), ", "), n.partialChecks)
+		issue.Context = status.Errors
+	case alertCategoryBlob:
+		issue.Name = "Blob Issue"
+		if status.Blob.Error != "" {
+			issue.Summary = "blob submission failed"
+			issue.Detail = "Test blob submission failed"
+			issue.Context = []string{fmt.Sprintf("Error: %s", status.Blob.Error)}
+			break
+		}
+		issue.Summary = "slow blob inclusion"
+		issue.Detail = fmt.Sprintf("Test blob took %.1f seconds to be included (max: %d)",
+			status.Blob.SubmitSeconds, e.config.Thresholds.Blob.MaxSubmitSeconds)
+		issue.Context = []string{fmt.Sprintf("Included at height: %d", status.Blob.Height)}
+	}
+
+	return issue
+}
+
+// recoverySection describes a recovered category in a recovery message
+func recoverySection(category string, status *Status) string {
+	switch category {
+	case alertCategoryChain:
+		return fmt.Sprintf("✅ Chain recovered: Node is at height %d", status.LocalHeight) + chainSuffix(status) + "\n\n"
+	case alertCategorySync:
+		return fmt.Sprintf("✅ Sync recovered: Node is %d blocks behind the network\n", status.HeightDiff) +
+			fmt.Sprintf("   Local Height: %d, Network Height: %d\n\n", status.LocalHeight, status.NetworkHeight)
+	case alertCategoryNetwork:
+		return fmt.Sprintf("✅ Network recovered: Node has %d peers\n", status.PeerCount) +
+			fmt.Sprintf("   NAT Status: %s\n\n", status.NATStatus)
+	case alertCategoryBandwidth:
+		return fmt.Sprintf("✅ Bandwidth recovered: In: %s, Out: %s\n\n", formatRate(status.Bandwidth.RateIn), formatRate(status.Bandwidth.RateOut))
+	case alertCategorySampling:
+		return fmt.Sprintf("✅ Sampling recovered: DASer is %d headers behind the network\n\n", status.Sampling.Behind)
+	case alertCategoryDisk:
+		return fmt.Sprintf("✅ Disk recovered: %.1f%% free on the data directory\n\n", 100-status.DiskPercentUsed)
+	case alertCategoryStore:
+		size, unit := formatDataSize(float64(status.StoreSizeBytes))
+		return fmt.Sprintf("✅ Store size recovered: Node store is %.2f %s\n\n", size, unit)
+	case alertCategoryResources:
+		memory, unit := formatDataSize(float64(status.Resources.MemoryBytes))
+		return fmt.Sprintf("✅ Memory recovered: Node libp2p stack uses %.2f %s\n\n", memory, unit)
+	case alertCategoryProcess:
+		if status.Process == nil {
+			return "✅ Process check disabled\n\n"
+		}
+		memory, unit := formatDataSize(float64(status.Process.RSSBytes))
+		return fmt.Sprintf("✅ Process recovered: Node process uses %.1f%% CPU and %.2f %s\n\n", status.Process.CPUPercent, memory, unit)
+	case alertCategoryLatency:
+		return fmt.Sprintf("✅ RPC latency recovered: Checks take %d ms on average\n\n", status.LatencyAvgMs)
+	case alertCategoryBlob:
+		if status.Blob == nil {
+			return "✅ Blob submission check disabled\n\n"
+		}
+		return fmt.Sprintf("✅ Blob submission recovered: Test blob included after %.1f seconds\n\n", status.Blob.SubmitSeconds)
+	case alertCategoryQueries:
+		return "✅ Queries recovered: Every part of the node answered the check\n\n"
+	}
+	return ""
+}
+
+// chainSuffix names the chain of a status, if known
+func chainSuffix(status *Status) string {
+	if status.ChainID == "" {
+		return ""
+	}
+	return fmt.Sprintf(" on chain %s", status.ChainID)
+}
+
+// sendRecovery notifies all configured channels that the node is healthy again.
+// Recoveries reach the same channels as the alerts of the unhealthy period.
+func (e *Engine) sendRecovery(n *nodeMonitor, previous, status *Status, downtime time.Duration) error {
+	message := fmt.Sprintf("[%s] ✅ Node recovered\n\n", status.DisplayName())
+
+	message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(status.Timestamp))
+	message += fmt.Sprintf("Unhealthy for: %s\n", downtime.Round(time.Second))
+	if acknowledgedBy := e.acknowledged(n); acknowledgedBy != "" {
+		message += fmt.Sprintf("Acknowledged by: %s\n", acknowledgedBy)
+	}
+	message += fmt.Sprintf("Height: %d/%d, Peers: %d\n\n", status.LocalHeight, status.NetworkHeight, status.PeerCount)
+
+	// Add a section for each category that was unhealthy before
+	recovered := unhealthyCategories(previous)
+	for _, category := range recovered {
+		message += recoverySection(category, status)
+	}
+
+	recovery := alert.Alert{
+		Kind:       alert.KindRecovery,
+		Severity:   max(n.episodeSeverity, alert.SeverityWarning),
+		Node:       status.Node,
+		Categories: recovered,
+		Message:    message,
+		Timestamp:  status.Timestamp,
+		Status:     status,
+		Facts:      statusFacts(status),
+		Summary:    statusSummary(status),
+		Escalated:  n.escalated,
+	}
+	silenced, err := e.send(recovery)
+	if err != nil {
+		return fmt.Errorf("[ERROR] failed to send alert: %w", err)
+	}
+
+	// Whoever got the problem alert should hear of the recovery once the
+	// silence is over
+	if silenced && e.alertingNode(n) {
+		recovery.Message = fmt.Sprintf("⏳ Recovered at %s while alerts were held back\n\n", e.config.FormatTime(status.Timestamp)) + recovery.Message
+		n.pendingRecovery = &recovery
+	}
+
+	return nil
+}
+
+// sendPendingRecovery retries the recovery held back by a maintenance
+// window or silence, keeping it while alerts are still held back
+func (e *Engine) sendPendingRecovery(n *nodeMonitor) error {
+	silenced, err := e.send(*n.pendingRecovery)
+	if silenced {
+		return nil
+	}
+	n.pendingRecovery = nil
+	if err != nil {
+		return fmt.Errorf("[ERROR] failed to send alert: %w", err)
+	}
+	return nil
+}
+
+// send delivers an alert. An alert held back by a maintenance window or
+// silence is logged rather than reported as an error.
+func (e *Engine) send(a alert.Alert) (silenced bool, err error) {
+	if e.sink != nil {
+		err = e.sink.Send(a)
+	} else {
+		err = e.alerter.Send(a)
+	}
+	if errors.Is(err, alert.ErrSilenced) {
+		e.log.Info(fmt.Sprintf("[%s] Alert suppressed for %s: %v", a.Node, strings.Join(a.Categories, ", "), err),
+			"node", a.Node, "categories", a.Categories, "reason", err.Error())
+		return true, nil
+	}
+	return false, err
+}
+
+// restartReason reports why the node appears to have restarted since the
+// previous check, or an empty string if it does not
+func restartReason(previous, status *Status) string {
+	if previous == nil {
+		return ""
+	}
+
+	switch {
+	case status.LocalHeight < previous.LocalHeight:
+		return fmt.Sprintf("local head went back from %d to %d", previous.LocalHeight, status.LocalHeight)
+	case status.NetworkHeight < previous.NetworkHeight:
+		return fmt.Sprintf("network head went back from %d to %d", previous.NetworkHeight, status.NetworkHeight)
+	case previous.NodeType != "" && status.NodeType != "" && previous.NodeType != status.NodeType:
+		return fmt.Sprintf("node type changed from %s to %s", previous.NodeType, status.NodeType)
+	case previous.APIVersion != "" && status.APIVersion != "" && previous.APIVersion != status.APIVersion:
+		return fmt.Sprintf("API version changed from %s to %s", previous.APIVersion, status.APIVersion)
+	}
+
+	return ""
+}
+
+// natChanged reports whether the NAT status changed since the previous
+// check in a way thresholds.network.nat_change_alert asks to alert on
+func natChanged(cfg *config.Config, previous, status *Status) bool {
+	// A failed NAT query is not a change
+	if previous == nil || previous.NATStatus == status.NATStatus || previous.NATStatus == "" || status.NATStatus == "" {
+		return false
+	}
+
+	switch cfg.Thresholds.Network.NATChangeAlert {
+	case "any":
+		return true
+	case "from_public":
+		return previous.NATStatus == "Public"
+	}
+	return false
+}
+
+// sendNATAlert notifies all configured channels that the NAT status changed
+func (e *Engine) sendNATAlert(previous, status *Status) error {
+	message := fmt.Sprintf("[%s] 🌐 NAT status changed from %s to %s\n\n", status.DisplayName(), previous.NATStatus, status.NATStatus)
+
+	message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(status.Timestamp))
+	message += fmt.Sprintf("Previous NAT Status: %s\n", previous.NATStatus)
+	message += fmt.Sprintf("Current NAT Status: %s\n", status.NATStatus)
+	if status.PeerDetails.Available {
+		message += fmt.Sprintf("Peers: %d (inbound: %d)\n", status.PeerCount, status.PeerDetails.Inbound)
+	} else {
+		message += fmt.Sprintf("Peers: %d\n", status.PeerCount)
+	}
+	if previous.NATStatus == "Public" {
+		message += "Check the router's port forwarding, peers may stop connecting to the node\n"
+	}
+
+	if _, err := e.send(alert.Alert{
+		Kind:       alert.KindNotice,
+		Severity:   alert.SeverityWarning,
+		Node:       status.Node,
+		Categories: []string{alertCategoryNAT},
+		Message:    message,
+		Timestamp:  status.Timestamp,
+		Status:     status,
+		Facts:      statusFacts(status),
+	}); err != nil {
+		return fmt.Errorf("[ERROR] failed to send alert: %w", err)
+	}
+
+	return nil
+}
+
+// sendRestartAlert notifies all configured channels that the node restarted
+func (e *Engine) sendRestartAlert(status *Status, reason string) error {
+	message := fmt.Sprintf("[%s] 🔄 Node appears to have restarted\n\n", status.DisplayName())
+
+	message += fmt.Sprintf("Time: %s\n", e.config.FormatTime(status.Timestamp))
+	message += fmt.Sprintf("Reason: %s\n", reason)
+	if status.NodeType != "" {
+		message += fmt.Sprintf("Node: %s %s\n", status.NodeType, status.APIVersion)
+	}
+
+	if _, err := e.send(alert.Alert{
+		Kind:       alert.KindNotice,
+		Severity:   alert.SeverityInfo,
+		Node:       status.Node,
+		Categories: []string{alertCategoryRestart},
+		Message:    message,
+		Timestamp:  status.Timestamp,
+		Status:     status,
+		Facts:      statusFacts(status),
+	}); err != nil {
+		return fmt.Errorf("[ERROR] failed to send alert: %w", err)
+	}
+
+	return nil
+}
+
+// statusFacts summarizes a status for channels that render structured messages
+func statusFacts(status *Status) []alert.Fact {
+	return []alert.Fact{
+		{Name: "Node", Value: status.Node},
+		{Name: "Local Height", Value: fmt.Sprintf("%d", status.LocalHeight)},
+		{Name: "Network Height", Value: fmt.Sprintf("%d", status.NetworkHeight)},
+		{Name: "Height Diff", Value: fmt.Sprintf("%d", status.HeightDiff)},
+		{Name: "Peers", Value: fmt.Sprintf("%d", status.PeerCount)},
+		{Name: "NAT Status", Value: status.NATStatus},
+		{Name: "Bandwidth", Value: fmt.Sprintf("In: %.1f KB/s, Out: %.1f KB/s", status.Bandwidth.RateIn/1024, status.Bandwidth.RateOut/1024)},
+	}
+}
+
+// statusSummary returns the heights and peers rendered by compact alert formats
+func statusSummary(status *Status) *alert.Summary {
+	return &alert.Summary{
+		Node:          status.DisplayName(),
+		LocalHeight:   status.LocalHeight,
+		NetworkHeight: status.NetworkHeight,
+		BlocksBehind:  status.BlocksBehind(),
+		Peers:         status.PeerCount,
+	}
+}
+
+// trendSummary returns the summary of the status along with the readings
+// of the node's recent checks
+func trendSummary(n *nodeMonitor, status *Status) *alert.Summary {
+	summary := statusSummary(status)
+	for _, recent := range n.recent {
+		summary.HeightDiffTrend = append(summary.HeightDiffTrend, recent.HeightDiff)
+		summary.PeerTrend = append(summary.PeerTrend, recent.PeerCount)
+	}
+	return summary
 }
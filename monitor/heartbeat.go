@@ -0,0 +1,42 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// pingHeartbeat pings the heartbeat URL after a successful check, or the
+// failure URL after a failed one. Ping errors are only logged, they must
+// not fail the check itself.
+func (e *Engine) pingHeartbeat(success bool) {
+	url := e.config.Heartbeat.URL
+	if !success {
+		url = e.config.Heartbeat.FailureURL
+	}
+	if url == "" {
+		return
+	}
+
+	timeout := time.Duration(e.config.Heartbeat.TimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(e.ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		e.log.Error(fmt.Sprintf("Heartbeat ping failed: %v", err), "error", err)
+		return
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		e.log.Error(fmt.Sprintf("Heartbeat ping failed: %v", err), "error", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		e.log.Error(fmt.Sprintf("Heartbeat ping returned non-OK status: %s", resp.Status), "status", resp.StatusCode)
+	}
+}
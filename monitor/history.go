@@ -0,0 +1,182 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// historyLines caches the number of lines in each history file this
+// process appends to, so the file is only read to count them once
+var (
+	historyMu    sync.Mutex
+	historyLines = make(map[string]int)
+)
+
+// AppendHistory appends statuses to the history file, one JSON object per
+// line, keeping about monitoring.max_history of the most recent entries
+// (0 keeps all). To spare rewriting the file on every check, it is only
+// trimmed back to max_history once it has grown a tenth past it.
+func AppendHistory(cfg *config.Config, statuses []*Status) error {
+	historyFile, err := cfg.HistoryFilePath()
+	if err != nil {
+		return err
+	}
+	maxLines := cfg.Monitoring.MaxHistory
+
+	if err := os.MkdirAll(filepath.Dir(historyFile), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, status := range statuses {
+		line, err := json.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("failed to marshal status: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	lines, counted := historyLines[historyFile]
+	if !counted && maxLines > 0 {
+		if lines, err = countLines(historyFile); err != nil {
+			return err
+		}
+	}
+
+	// A single write keeps readers from seeing part of a check
+	file, err := os.OpenFile(historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	lines += len(statuses)
+
+	if maxLines > 0 && lines > maxLines+max(maxLines/10, 1) {
+		if lines, err = trimHistory(historyFile, maxLines); err != nil {
+			delete(historyLines, historyFile)
+			return err
+		}
+	}
+	historyLines[historyFile] = lines
+
+	return nil
+}
+
+// countLines returns the number of entries in the history file, 0 if it
+// does not exist
+func countLines(historyFile string) (int, error) {
+	file, err := os.Open(historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read history file: %w", err)
+	}
+	defer file.Close()
+
+	lines := 0
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := file.Read(buf)
+		lines += bytes.Count(buf[:n], []byte("\n"))
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read history file: %w", err)
+		}
+	}
+}
+
+// trimHistory rewrites the history file with its last maxLines entries
+// and returns the number of entries kept
+func trimHistory(historyFile string, maxLines int) (int, error) {
+	data, err := os.ReadFile(historyFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var lines [][]byte
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	// Write to a temporary file first so readers never see a partial file
+	tmpFile := historyFile + ".tmp"
+	if err := os.WriteFile(tmpFile, buf.Bytes(), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, historyFile); err != nil {
+		return 0, fmt.Errorf("failed to replace history file: %w", err)
+	}
+
+	return len(lines), nil
+}
+
+// LoadHistory reads all statuses from the history file, oldest first
+func LoadHistory(cfg *config.Config) ([]*Status, error) {
+	historyFile, err := cfg.HistoryFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("history file not found, is 'celestia-watchtower start' running?")
+		}
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	defer file.Close()
+
+	var history []*Status
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		status := &Status{}
+		if err := json.Unmarshal(line, status); err != nil {
+			return nil, fmt.Errorf("failed to parse history file: %w", err)
+		}
+		history = append(history, status)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,34 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+func TestAppendHistoryTrimsWithMargin(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	const maxLines = 20
+	cfg := config.DefaultConfig()
+	cfg.Monitoring.MaxHistory = maxLines
+	for i := 1; i <= 25; i++ {
+		if err := AppendHistory(cfg, []*Status{{Node: "test", LocalHeight: uint64(i)}}); err != nil {
+			t.Fatalf("AppendHistory: %v", err)
+		}
+		history, err := LoadHistory(cfg)
+		if err != nil {
+			t.Fatalf("LoadHistory: %v", err)
+		}
+		// Trimmed back to maxLines once it passes the margin of 2
+		want := i
+		if i > maxLines+2 {
+			want = maxLines + (i-maxLines-3)%3
+		}
+		if len(history) != want {
+			t.Fatalf("after %d appends the history has %d entries, want %d", i, len(history), want)
+		}
+		if last := history[len(history)-1].LocalHeight; last != uint64(i) {
+			t.Fatalf("last entry has height %d, want %d", last, i)
+		}
+	}
+}
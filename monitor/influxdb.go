@@ -0,0 +1,111 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// influxMaxPending bounds the points kept while InfluxDB is unreachable,
+// the oldest are dropped first
+const influxMaxPending = 10000
+
+// influxTagEscaper escapes the characters line protocol reserves in tag values
+var influxTagEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+
+// influxWriter batches check results and writes them to InfluxDB
+type influxWriter struct {
+	mu      sync.Mutex
+	pending []string
+}
+
+// influxPoint formats a status as a line protocol point
+func influxPoint(status *Status) string {
+	return fmt.Sprintf("celestia_watchtower,node=%s local_height=%di,network_height=%di,height_diff=%di,peers=%di,check_duration_ms=%di,healthy=%t %d",
+		influxTagEscaper.Replace(status.Node),
+		status.LocalHeight,
+		status.NetworkHeight,
+		status.HeightDiff,
+		status.PeerCount,
+		status.CheckDurationMs,
+		status.Healthy,
+		status.Timestamp.Unix())
+}
+
+// exportInfluxDB buffers the points of the checked statuses and writes
+// them once a batch is full
+func (e *Engine) exportInfluxDB(statuses []*Status) error {
+	if !e.config.Monitoring.InfluxDB.Enabled {
+		return nil
+	}
+
+	e.influx.mu.Lock()
+	defer e.influx.mu.Unlock()
+
+	for _, status := range statuses {
+		e.influx.pending = append(e.influx.pending, influxPoint(status))
+	}
+	if len(e.influx.pending) < e.config.Monitoring.InfluxDB.BatchSize {
+		return nil
+	}
+	return e.writeInfluxDB()
+}
+
+// flushInfluxDB writes the buffered points regardless of the batch size
+func (e *Engine) flushInfluxDB() error {
+	e.influx.mu.Lock()
+	defer e.influx.mu.Unlock()
+
+	if !e.config.Monitoring.InfluxDB.Enabled || len(e.influx.pending) == 0 {
+		return nil
+	}
+	return e.writeInfluxDB()
+}
+
+// writeInfluxDB sends the buffered points through the InfluxDB v2 write
+// API. Points that could not be written stay buffered for the next write.
+// The caller must hold e.influx.mu.
+func (e *Engine) writeInfluxDB() error {
+	cfg := e.config.Monitoring.InfluxDB
+
+	if len(e.influx.pending) > influxMaxPending {
+		e.influx.pending = e.influx.pending[len(e.influx.pending)-influxMaxPending:]
+	}
+
+	query := url.Values{}
+	query.Set("org", cfg.Org)
+	query.Set("bucket", cfg.Bucket)
+	query.Set("precision", "s")
+	writeURL := strings.TrimSuffix(cfg.URL, "/") + "/api/v2/write?" + query.Encode()
+
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	body := strings.Join(e.influx.pending, "\n") + "\n"
+	req, err := http.NewRequestWithContext(ctx, "POST", writeURL, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create InfluxDB request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+cfg.Token)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write to InfluxDB: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("InfluxDB returned non-OK status: %s: %s", resp.Status, strings.TrimSpace(string(snippet)))
+	}
+
+	e.influx.pending = nil
+	return nil
+}
@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// processUsage is a reading of the node process from /proc
+type processUsage struct {
+	startTicks uint64  // start time of the process, tells a restarted process with a reused pid apart
+	cpuSeconds float64 // CPU time used since the process started
+	rssBytes   int64
+}
+
+// processSample is the previous reading of the node process, the CPU use
+// is the CPU time spent between two readings
+type processSample struct {
+	pid   int
+	at    time.Time
+	usage processUsage
+}
+
+// checkProcess reads the CPU and memory use of the node process set with
+// process_unit or process_pid_file and applies thresholds.process. The CPU
+// use is measured between two checks, so the first check of a process only
+// has its memory. The check does nothing where /proc is not available.
+func (e *Engine) checkProcess(n *nodeMonitor, status *Status) {
+	if !processSupported || (n.config.ProcessUnit == "" && n.config.ProcessPIDFile == "") {
+		return
+	}
+
+	pid, err := processPID(e.ctx, n.config)
+	var usage processUsage
+	if err == nil {
+		usage, err = readProcess(pid)
+	}
+	if err != nil {
+		n.process = processSample{}
+		status.addError(subsystemProcess, err)
+		status.QueriesHealthy = false
+		status.Healthy = false
+		status.Severity = statusSeverity(e.config, status)
+		return
+	}
+
+	check := &ProcessCheck{PID: pid, RSSBytes: usage.rssBytes}
+	previous := n.process
+	if previous.pid == pid && previous.usage.startTicks == usage.startTicks {
+		if elapsed := status.Timestamp.Sub(previous.at).Seconds(); elapsed > 0 {
+			check.CPUPercent = (usage.cpuSeconds - previous.usage.cpuSeconds) / elapsed * 100
+		}
+	}
+	n.process = processSample{pid: pid, at: status.Timestamp, usage: usage}
+	status.Process = check
+
+	_, high := processLimits(e.config.Thresholds.Process.MaxCPUPercent, e.config.Thresholds.Process.MaxMemoryMB, check)
+	status.ProcessHealthy = len(high) == 0
+	status.Healthy = len(unhealthyCategories(status)) == 0
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// processLimits returns the summary and the description of the limits the
+// node process is above, none if it is within them
+func processLimits(maxCPUPercent float64, maxMemoryMB int, check *ProcessCheck) (string, []string) {
+	var names, high []string
+	if maxCPUPercent > 0 && check.CPUPercent > maxCPUPercent {
+		names = append(names, "CPU")
+		high = append(high, fmt.Sprintf("CPU: %.1f%% (max: %.0f%%)", check.CPUPercent, maxCPUPercent))
+	}
+	if maxMemoryMB > 0 && check.RSSBytes > int64(maxMemoryMB)*1024*1024 {
+		memory, unit := formatDataSize(float64(check.RSSBytes))
+		names = append(names, "memory")
+		high = append(high, fmt.Sprintf("Memory: %.2f %s (max: %d MB)", memory, unit, maxMemoryMB))
+	}
+	return "high " + strings.Join(names, " and "), high
+}
@@ -0,0 +1,96 @@
+//go:build linux
+
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// processSupported reports whether the node process can be read on this platform
+const processSupported = true
+
+// clockTicks is the rate the kernel reports CPU times in, USER_HZ is 100
+// on every architecture Go runs on
+const clockTicks = 100
+
+// processLookupTimeout bounds the systemctl call finding a unit's process
+const processLookupTimeout = 5 * time.Second
+
+// processPID returns the pid of the node process, from the pid file or the
+// main process of the systemd unit
+func processPID(ctx context.Context, node config.NodeConfig) (int, error) {
+	if node.ProcessPIDFile != "" {
+		data, err := os.ReadFile(node.ProcessPIDFile)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read pid file: %w", err)
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil || pid <= 0 {
+			return 0, fmt.Errorf("pid file %s does not hold a pid", node.ProcessPIDFile)
+		}
+		return pid, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, processLookupTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "systemctl", "show", "--property=MainPID", "--value", node.ProcessUnit).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up unit %s: %w", node.ProcessUnit, err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected main pid %q of unit %s", strings.TrimSpace(string(out)), node.ProcessUnit)
+	}
+	if pid == 0 {
+		return 0, fmt.Errorf("unit %s has no running process", node.ProcessUnit)
+	}
+	return pid, nil
+}
+
+// readProcess reads the CPU time and resident memory of a process from /proc/<pid>/stat
+func readProcess(pid int) (processUsage, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return processUsage{}, fmt.Errorf("process %d is not running", pid)
+		}
+		return processUsage{}, fmt.Errorf("failed to read process %d: %w", pid, err)
+	}
+
+	// The command name in parentheses may hold spaces, the fields from the
+	// state on follow the last parenthesis
+	stat := string(data)
+	end := strings.LastIndexByte(stat, ')')
+	if end < 0 {
+		return processUsage{}, fmt.Errorf("unexpected stat of process %d", pid)
+	}
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 22 {
+		return processUsage{}, fmt.Errorf("unexpected stat of process %d", pid)
+	}
+
+	// utime, stime, starttime and rss are fields 14, 15, 22 and 24 of proc(5)
+	var values [4]uint64
+	for i, index := range []int{11, 12, 19, 21} {
+		values[i], err = strconv.ParseUint(fields[index], 10, 64)
+		if err != nil {
+			return processUsage{}, fmt.Errorf("unexpected stat of process %d: %w", pid, err)
+		}
+	}
+
+	return processUsage{
+		startTicks: values[2],
+		cpuSeconds: float64(values[0]+values[1]) / clockTicks,
+		rssBytes:   int64(values[3]) * int64(os.Getpagesize()),
+	}, nil
+}
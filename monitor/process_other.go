@@ -0,0 +1,23 @@
+//go:build !linux
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// processSupported reports whether the node process can be read on this platform
+const processSupported = false
+
+// processPID is not supported on this platform
+func processPID(ctx context.Context, node config.NodeConfig) (int, error) {
+	return 0, fmt.Errorf("process check is not supported on this platform")
+}
+
+// readProcess is not supported on this platform
+func readProcess(pid int) (processUsage, error) {
+	return processUsage{}, fmt.Errorf("process check is not supported on this platform")
+}
@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/21state/celestia-watchtower/alert"
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// reload re-reads the config file and applies thresholds, monitoring and
+// alert settings to the running engine. An invalid config is rejected and
+// the current one is kept. Node and logging settings need a restart.
+func (e *Engine) reload(ticker *time.Ticker) {
+	e.log.Info("Received SIGHUP, reloading configuration")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		e.log.Error(fmt.Sprintf("Reload rejected, keeping the current configuration: %v", err), "error", err)
+		return
+	}
+
+	if errs := config.Validate(cfg); len(errs) > 0 {
+		for _, err := range errs {
+			e.log.Error(fmt.Sprintf("Invalid configuration: %v", err), "error", err)
+		}
+		e.log.Error("Reload rejected, keeping the current configuration")
+		return
+	}
+
+	alerter, err := alert.NewManager(cfg)
+	if err != nil {
+		e.log.Error(fmt.Sprintf("Reload rejected, keeping the current configuration: %v", err), "error", err)
+		return
+	}
+	alerter.SetStatusDecoder(decodeStatus)
+
+	old := e.config
+
+	// The RPC clients stay connected, so node changes wait for a restart
+	if !reflect.DeepEqual(cfg.Node, old.Node) {
+		e.log.Warn("Node settings changed; restart the watchtower to apply them")
+		cfg.Node = old.Node
+	}
+	if cfg.Logging != old.Logging {
+		e.log.Warn("Logging settings changed; restart the watchtower to apply them")
+	}
+
+	var changed []string
+	if cfg.Monitoring != old.Monitoring {
+		changed = append(changed, "monitoring")
+	}
+	if !reflect.DeepEqual(cfg.Thresholds, old.Thresholds) {
+		changed = append(changed, "thresholds")
+	}
+	if !reflect.DeepEqual(cfg.Alerts, old.Alerts) {
+		changed = append(changed, "alerts")
+	}
+	if cfg.Heartbeat != old.Heartbeat {
+		changed = append(changed, "heartbeat")
+	}
+
+	e.mu.Lock()
+	previous := e.alerter
+	e.config = cfg
+	e.alerter = alerter
+	e.mu.Unlock()
+	previous.Close()
+	e.startTelegramPoller(alerter)
+
+	if cfg.Monitoring.CheckInterval != old.Monitoring.CheckInterval {
+		ticker.Reset(time.Duration(cfg.Monitoring.CheckInterval) * time.Second)
+		e.log.Info(fmt.Sprintf("Check interval changed from %d to %d seconds", old.Monitoring.CheckInterval, cfg.Monitoring.CheckInterval),
+			"old_interval_seconds", old.Monitoring.CheckInterval, "interval_seconds", cfg.Monitoring.CheckInterval)
+	}
+
+	if oldChannels, channels := previous.EnabledChannels(), alerter.EnabledChannels(); !reflect.DeepEqual(oldChannels, channels) {
+		e.log.Info(fmt.Sprintf("Alert channels changed from [%s] to [%s]", strings.Join(oldChannels, ", "), strings.Join(channels, ", ")),
+			"old_channels", oldChannels, "channels", channels)
+	}
+
+	if len(changed) == 0 {
+		e.log.Info("Configuration reloaded, nothing changed")
+		return
+	}
+	e.log.Info(fmt.Sprintf("Configuration reloaded, changed: %s", strings.Join(changed, ", ")), "changed", changed)
+}
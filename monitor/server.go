@@ -0,0 +1,86 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// httpShutdownTimeout bounds how long in-flight requests may take on shutdown
+const httpShutdownTimeout = 5 * time.Second
+
+// StartHTTPServer serves health endpoints on addr until the engine stops:
+//
+//	/healthz  200 while the process is alive
+//	/readyz   200 if every node is healthy, 503 otherwise
+//	/status   the last status of each node as JSON
+func (e *Engine) StartHTTPServer(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("[ERROR] failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", e.handleHealthz)
+	mux.HandleFunc("/readyz", e.handleReadyz)
+	mux.HandleFunc("/status", e.handleStatus)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.log.Error(fmt.Sprintf("HTTP server failed: %v", err), "error", err)
+		}
+	}()
+
+	// Shut down together with the engine
+	go func() {
+		<-e.ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			e.log.Error(fmt.Sprintf("HTTP server shutdown failed: %v", err), "error", err)
+		}
+	}()
+
+	e.log.Info(fmt.Sprintf("HTTP server listening on %s", listener.Addr()), "addr", listener.Addr().String())
+	return nil
+}
+
+// handleHealthz reports that the process is alive
+func (e *Engine) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+// handleReadyz reports whether every node was healthy at the last check
+func (e *Engine) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ready := e.allHealthy()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "unhealthy")
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// handleStatus serves the last status of each node as JSON
+func (e *Engine) handleStatus(w http.ResponseWriter, r *http.Request) {
+	data, err := json.MarshalIndent(e.GetLastStatus(), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
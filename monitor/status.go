@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/21state/celestia-watchtower/config"
+)
+
+// SaveStatus writes the latest status of each node to the status file
+// configured in cfg, with its configured permissions
+func SaveStatus(cfg *config.Config, statuses map[string]*Status) error {
+	statusFile, err := cfg.StatusFilePath()
+	if err != nil {
+		return err
+	}
+	mode := cfg.StatusFilePerm()
+
+	// The directory can be entered by whoever may read the file
+	if err := os.MkdirAll(filepath.Dir(statusFile), mode|(mode&0444)>>2); err != nil {
+		return fmt.Errorf("failed to create status directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial file.
+	// The mode is set explicitly since the umask applies on creation.
+	tmpFile := statusFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, mode); err != nil {
+		return fmt.Errorf("failed to write status file: %w", err)
+	}
+	if err := os.Chmod(tmpFile, mode); err != nil {
+		return fmt.Errorf("failed to set status file permissions: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, statusFile); err != nil {
+		return fmt.Errorf("failed to replace status file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadStatus reads the latest status of each node from the status file
+// configured in cfg
+func LoadStatus(cfg *config.Config) (map[string]*Status, error) {
+	statusFile, err := cfg.StatusFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(statusFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("status file not found, is 'celestia-watchtower start' running?")
+		}
+		return nil, fmt.Errorf("failed to read status file: %w", err)
+	}
+
+	statuses := make(map[string]*Status)
+	if err := json.Unmarshal(data, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to parse status file: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// decodeStatus decodes a status as written by json.Marshal, the decoder of
+// the alert outbox
+func decodeStatus(data []byte) (interface{}, error) {
+	status := &Status{}
+	if err := json.Unmarshal(data, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
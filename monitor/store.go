@@ -0,0 +1,104 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+)
+
+// storeGrowthWindow is how far back the store size measurements behind the
+// growth rate go
+const storeGrowthWindow = 24 * time.Hour
+
+// storeGrowthMinSpan is how far apart the measurements must be for a growth
+// rate, shorter spans are dominated by compaction
+const storeGrowthMinSpan = time.Hour
+
+// storeSample is a measurement of the size of a node store
+type storeSample struct {
+	at   time.Time
+	size int64
+}
+
+// checkStore measures the size of the node store when it is due, every
+// store_interval_minutes, and applies the latest measurement and the
+// growth rate over the last day to the status. A store larger than
+// thresholds.disk.max_store_size_gb is unhealthy.
+func (e *Engine) checkStore(n *nodeMonitor, status *Status) {
+	if n.config.StorePath == "" {
+		return
+	}
+
+	interval := time.Duration(n.config.StoreIntervalMinutes) * time.Minute
+	if len(n.storeSamples) == 0 || status.Timestamp.Sub(n.storeSamples[len(n.storeSamples)-1].at) >= interval {
+		size, err := dirSize(n.config.StorePath)
+		if err != nil {
+			status.addError(subsystemStore, err)
+			status.QueriesHealthy = false
+			status.Healthy = false
+			status.Severity = statusSeverity(e.config, status)
+			return
+		}
+
+		n.storeSamples = append(n.storeSamples, storeSample{at: status.Timestamp, size: size})
+		for len(n.storeSamples) > 1 && status.Timestamp.Sub(n.storeSamples[0].at) > storeGrowthWindow {
+			n.storeSamples = n.storeSamples[1:]
+		}
+	}
+	if len(n.storeSamples) == 0 {
+		return
+	}
+
+	first, last := n.storeSamples[0], n.storeSamples[len(n.storeSamples)-1]
+	status.StoreSizeBytes = last.size
+	if span := last.at.Sub(first.at); span >= storeGrowthMinSpan {
+		status.StoreGrowthPerDay = int64(float64(last.size-first.size) * float64(24*time.Hour) / float64(span))
+	}
+
+	maxGB := e.config.Thresholds.Disk.MaxStoreSizeGB
+	status.StoreHealthy = maxGB <= 0 || last.size <= int64(maxGB)*1024*1024*1024
+	status.Healthy = len(unhealthyCategories(status)) == 0
+	status.Severity = statusSeverity(e.config, status)
+}
+
+// formatGrowth formats a store growth rate, e.g. "+1.20 GB/day"
+func formatGrowth(bytesPerDay int64) string {
+	sign := "+"
+	if bytesPerDay < 0 {
+		sign, bytesPerDay = "-", -bytesPerDay
+	}
+	growth, unit := formatDataSize(float64(bytesPerDay))
+	return fmt.Sprintf("%s%.2f %s/day", sign, growth, unit)
+}
+
+// dirSize returns the total size of the regular files below path. Files
+// removed during the walk, as the node compacts its store, are skipped.
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if name != path && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure store %s: %w", path, err)
+	}
+	return size, nil
+}
@@ -2,26 +2,58 @@ package monitor
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/21state/celestia-watchtower/alert"
 	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/logging"
 	"github.com/21state/celestia-watchtower/rpc"
 )
 
 // Status represents the node status
 type Status struct {
+	Node      string    `json:"node"`
+	Label     string    `json:"label,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
+
+	// Node software, empty if the token lacks admin permission
+	NodeType   string    `json:"node_type,omitempty"`
+	APIVersion string    `json:"api_version,omitempty"`
+	FirstSeen  time.Time `json:"first_seen"` // when the current node process was first seen
 	
 	// Sync status
 	NetworkHeight uint64 `json:"network_height"`
 	LocalHeight   uint64 `json:"local_height"`
 	HeightDiff    int64  `json:"height_diff"`
 	SyncHealthy   bool   `json:"sync_healthy"`
+	StalledFor    int64  `json:"stalled_for_seconds,omitempty"` // how long the local head has been stuck while behind
+
+	NetworkHeadAge int64 `json:"network_head_age_seconds"` // age of the newest network header
 	
+	// Chain the node is on, empty unless an expected chain ID is configured
+	ChainID      string `json:"chain_id,omitempty"`
+	ChainHealthy bool   `json:"chain_healthy"` // on the expected chain, at or above the expected minimum height and matching the checkpoints
+
+	// Latest verification of the trusted checkpoints, nil without checkpoints
+	Checkpoint *CheckpointCheck `json:"checkpoint,omitempty"`
+
 	// Network status
 	PeerCount   int    `json:"peer_count"`
 	NATStatus   string `json:"nat_status"`
 	NetHealthy  bool   `json:"net_healthy"`
+
+	// Average peer count the network health is based on, when
+	// thresholds.network.peer_smoothing_checks is set
+	PeerCountSmoothed float64 `json:"peer_count_smoothed,omitempty"`
+
+	// Connection directions of the peers, if the node exposes them
+	PeerDetails struct {
+		Available bool `json:"available"`
+		Inbound   int  `json:"inbound"`
+		Outbound  int  `json:"outbound"`
+	} `json:"peer_details"`
 	
 	// Bandwidth stats
 	Bandwidth struct {
@@ -30,66 +62,502 @@ type Status struct {
 		RateIn   float64 `json:"rate_in"`
 		RateOut  float64 `json:"rate_out"`
 	} `json:"bandwidth"`
+	BandwidthHealthy   bool `json:"bandwidth_healthy"`
+	LowBandwidthChecks int  `json:"low_bandwidth_checks,omitempty"` // consecutive checks below a bandwidth threshold
+	
+	// DAS sampling status
+	Sampling struct {
+		SampledHeight uint64 `json:"sampled_height"`
+		CatchupHead   uint64 `json:"catchup_head"`
+		NetworkHead   uint64 `json:"network_head"`
+		CatchUpDone   bool   `json:"catch_up_done"`
+		IsRunning     bool   `json:"is_running"`
+		Behind        int64  `json:"behind"`
+		Available     bool   `json:"available"` // false when the check is off or the node has no DAS module
+	} `json:"sampling"`
+	SamplingHealthy bool `json:"sampling_healthy"`
+
+	// Resource usage of the node's libp2p stack, if the node exposes it
+	Resources struct {
+		Available   bool  `json:"available"`
+		MemoryBytes int64 `json:"memory_bytes"`
+		Conns       int   `json:"conns"`
+		Streams     int   `json:"streams"`
+		FDs         int   `json:"fds"`
+	} `json:"resources"`
+	ResourcesHealthy bool `json:"resources_healthy"`
+
+	// Disk status
+	DiskFreeBytes   uint64  `json:"disk_free_bytes"`
+	DiskPercentUsed float64 `json:"disk_percent_used"`
+	DiskHealthy     bool    `json:"disk_healthy"`
+
+	// Size of the node store, if a store path is configured
+	StoreSizeBytes    int64 `json:"store_size_bytes,omitempty"`
+	StoreGrowthPerDay int64 `json:"store_growth_bytes_per_day,omitempty"` // over the measurements of the last day
+	StoreHealthy      bool  `json:"store_healthy"`
+
+	// CPU and memory use of the node process, if a process is configured
+	Process        *ProcessCheck `json:"process,omitempty"`
+	ProcessHealthy bool          `json:"process_healthy"`
+
+	// Duration of the check and of each RPC call in it, retries included
+	CheckDurationMs int64            `json:"check_duration_ms"`
+	RPCDurationsMs  map[string]int64 `json:"rpc_durations_ms,omitempty"`
+	LatencyAvgMs    int64            `json:"latency_avg_ms,omitempty"` // moving average of the check duration
+	LatencyHealthy  bool             `json:"latency_healthy"`
+
+	// Latest blob submission check, if enabled
+	Blob        *BlobCheck `json:"blob,omitempty"`
+	BlobHealthy bool       `json:"blob_healthy"`
+
+	// Queries that failed, as "subsystem: error". The fields they fill keep
+	// their zero values and are not judged, the rest of the check stands.
+	Errors         []string `json:"errors,omitempty"`
+	QueriesHealthy bool     `json:"queries_healthy"`
 	
 	// Overall status
-	Healthy bool `json:"healthy"`
+	Healthy  bool   `json:"healthy"`
+	Severity string `json:"severity"` // SeverityHealthy, SeverityWarning or SeverityCritical
+}
+
+// Subsystems whose failed queries leave a partial status
+const (
+	subsystemChain     = "chain"
+	subsystemPeers     = "peers"
+	subsystemNAT       = "nat"
+	subsystemBandwidth = "bandwidth"
+	subsystemSampling  = "sampling"
+	subsystemDisk      = "disk"
+	subsystemStore     = "store"
+	subsystemProcess   = "process"
+)
+
+// addError records a failed query of a subsystem
+func (s *Status) addError(subsystem string, err error) {
+	s.Errors = append(s.Errors, subsystem+": "+strings.TrimPrefix(err.Error(), "[ERROR] "))
+}
+
+// queryFailed reports whether the query of a subsystem failed in this check
+func (s *Status) queryFailed(subsystem string) bool {
+	for _, err := range s.Errors {
+		if strings.HasPrefix(err, subsystem+": ") {
+			return true
+		}
+	}
+	return false
+}
+
+// failedSubsystems returns the subsystems whose queries failed
+func (s *Status) failedSubsystems() []string {
+	subsystems := make([]string, len(s.Errors))
+	for i, err := range s.Errors {
+		subsystems[i], _, _ = strings.Cut(err, ": ")
+	}
+	return subsystems
+}
+
+// Overall severities of a status
+const (
+	SeverityHealthy  = "healthy"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// BlocksBehind returns how far the node is behind the network, 0 if the
+// local head is ahead of the network head
+func (s *Status) BlocksBehind() int64 {
+	return max(s.HeightDiff, 0)
+}
+
+// HeightSummary describes the height difference for display, e.g.
+// "3 behind" or "2 ahead"
+func (s *Status) HeightSummary() string {
+	if s.HeightDiff < 0 {
+		return fmt.Sprintf("%d ahead", -s.HeightDiff)
+	}
+	return fmt.Sprintf("%d behind", s.HeightDiff)
+}
+
+// DisplayName returns the node name followed by its label, as in
+// config.NodeConfig.DisplayName
+func (s *Status) DisplayName() string {
+	return config.NodeConfig{Name: s.Node, Label: s.Label}.DisplayName()
+}
+
+// SampleStatus returns a healthy status with typical values for a node,
+// for test alerts to render webhook templates with
+func SampleStatus(node config.NodeConfig) *Status {
+	now := time.Now()
+	status := &Status{
+		Node:             node.Name,
+		Label:            node.Label,
+		Timestamp:        now,
+		NodeType:         "light",
+		FirstSeen:        now.Add(-24 * time.Hour),
+		NetworkHeight:    1000000,
+		LocalHeight:      1000000,
+		SyncHealthy:      true,
+		ChainID:          node.ExpectedChainID,
+		ChainHealthy:     true,
+		PeerCount:        20,
+		NATStatus:        "Public",
+		NetHealthy:       true,
+		BandwidthHealthy: true,
+		SamplingHealthy:  true,
+		ResourcesHealthy: true,
+		DiskHealthy:      true,
+		StoreHealthy:     true,
+		ProcessHealthy:   true,
+		LatencyHealthy:   true,
+		BlobHealthy:      true,
+		QueriesHealthy:   true,
+		Healthy:          true,
+		Severity:         SeverityHealthy,
+	}
+	status.PeerDetails.Available = true
+	status.PeerDetails.Inbound = 10
+	status.PeerDetails.Outbound = 10
+	return status
+}
+
+// Indicator returns the health label shown in status output
+func (s *Status) Indicator() string {
+	switch {
+	case s.Healthy:
+		return "[OK] HEALTHY"
+	case s.Severity == SeverityWarning:
+		return "[WARN] WARNING"
+	default:
+		return "[!!] UNHEALTHY"
+	}
+}
+
+// ColoredIndicator returns the health label, colored green, yellow or red
+// by health when color is set
+func (s *Status) ColoredIndicator(color bool) string {
+	switch {
+	case s.Healthy:
+		return logging.Paint(color, logging.ColorGreen, s.Indicator())
+	case s.Severity == SeverityWarning:
+		return logging.Paint(color, logging.ColorYellow, s.Indicator())
+	default:
+		return logging.Paint(color, logging.ColorRed, s.Indicator())
+	}
+}
+
+// BlobCheck is the result of submitting a test blob
+type BlobCheck struct {
+	CheckedAt     time.Time `json:"checked_at"`
+	SubmitSeconds float64   `json:"submit_seconds"` // time from submission to inclusion
+	Height        uint64    `json:"height,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// ProcessCheck is the CPU and memory use of the node process
+type ProcessCheck struct {
+	PID        int     `json:"pid"`
+	CPUPercent float64 `json:"cpu_percent"` // since the previous check, 100 is one full core
+	RSSBytes   int64   `json:"rss_bytes"`
+}
+
+// CheckpointCheck is the result of verifying the node's headers against
+// the trusted checkpoints
+type CheckpointCheck struct {
+	CheckedAt  time.Time            `json:"checked_at"`
+	Verified   int                  `json:"verified"` // checkpoints the node's headers match
+	Mismatches []CheckpointMismatch `json:"mismatches,omitempty"`
+	Error      string               `json:"error,omitempty"` // headers that could not be fetched
+}
+
+// CheckpointMismatch is a trusted checkpoint the node's header differs from
+type CheckpointMismatch struct {
+	Height   uint64 `json:"height"`
+	Expected string `json:"expected"`
+	Hash     string `json:"hash"`
+}
+
+// NodeClient is the part of the node API a check queries, implemented by
+// *rpc.Client. The methods are called concurrently.
+type NodeClient interface {
+	GetNodeInfo() (*rpc.NodeInfo, error)
+	GetNetworkHead() (uint64, time.Time, error)
+	GetLocalHead() (uint64, error)
+	GetChainID() (string, error)
+	GetPeers() (int, error)
+	GetPeerDetails() (*rpc.PeerDetails, error)
+	GetNATStatus() (string, error)
+	GetBandwidthStats() (*rpc.BandwidthStats, error)
+	GetResourceStats() (*rpc.ResourceStats, error)
+	GetSamplingStats() (*rpc.SamplingStats, error)
+	CallDurations(since time.Time) map[string]time.Duration
 }
 
 // CheckNodeStatus checks the node status and returns a Status object
-func CheckNodeStatus(client *rpc.Client, cfg *config.Config) (*Status, error) {
+func CheckNodeStatus(client NodeClient, cfg *config.Config, node config.NodeConfig) (*Status, error) {
 	status := &Status{
 		Timestamp: time.Now(),
 	}
 
+	// Query the node concurrently so a slow endpoint costs one round-trip
+	var (
+		wg             sync.WaitGroup
+		info           *rpc.NodeInfo
+		infoErr        error
+		networkHeight  uint64
+		networkTime    time.Time
+		networkErr     error
+		localHeight    uint64
+		localErr       error
+		peerCount      int
+		peerErr        error
+		natStatus      string
+		natErr         error
+		bandwidthStats *rpc.BandwidthStats
+		bandwidthErr   error
+		resourceStats  *rpc.ResourceStats
+		resourceErr    error
+		peerDetails    *rpc.PeerDetails
+		peerDetailsErr error
+		chainID        string
+		chainErr       error
+	)
+	calls := []func(){
+		func() { info, infoErr = client.GetNodeInfo() },
+		func() { networkHeight, networkTime, networkErr = client.GetNetworkHead() },
+		func() { localHeight, localErr = client.GetLocalHead() },
+		func() { peerCount, peerErr = client.GetPeers() },
+		func() { natStatus, natErr = client.GetNATStatus() },
+		func() { bandwidthStats, bandwidthErr = client.GetBandwidthStats() },
+		func() { resourceStats, resourceErr = client.GetResourceStats() },
+		func() { peerDetails, peerDetailsErr = client.GetPeerDetails() },
+	}
+	if node.ExpectedChainID != "" {
+		calls = append(calls, func() { chainID, chainErr = client.GetChainID() })
+	}
+	wg.Add(len(calls))
+	for _, call := range calls {
+		go func(call func()) {
+			defer wg.Done()
+			call()
+		}(call)
+	}
+	wg.Wait()
+
+	// Node info needs an admin token, so it is optional. A configured type
+	// takes precedence over the detected one.
+	if infoErr == nil {
+		status.NodeType = info.Type
+		status.APIVersion = info.APIVersion
+	}
+	if node.Type != "" && node.Type != "auto" {
+		status.NodeType = node.Type
+	}
+
+	// Both heights are needed for the sync reading, which every other check
+	// relies on, so without them the check fails. A failed query of another
+	// subsystem is recorded in Errors and leaves the rest of the status.
+
 	// Check network height
-	networkHeight, err := client.GetNetworkHead()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] failed to get network height: %w", err)
+	if networkErr != nil {
+		return nil, fmt.Errorf("[ERROR] failed to get network height: %w", networkErr)
 	}
 	status.NetworkHeight = networkHeight
+	// A header from the future, by clock skew, counts as new
+	status.NetworkHeadAge = max(int64(status.Timestamp.Sub(networkTime).Seconds()), 0)
 	
 	// Check local height
-	localHeight, err := client.GetLocalHead()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] failed to get local height: %w", err)
+	if localErr != nil {
+		return nil, fmt.Errorf("[ERROR] failed to get local height: %w", localErr)
 	}
 	status.LocalHeight = localHeight
 	
-	// Calculate height difference
+	// Calculate height difference. It is negative when the gossiped network
+	// head briefly lags the local head, which counts as fully synced.
 	status.HeightDiff = int64(networkHeight) - int64(localHeight)
 	
-	// Check sync health
-	status.SyncHealthy = status.HeightDiff <= int64(cfg.Thresholds.SyncStatus.BlocksBehindCritical)
+	// Check sync health, the warning band starts before the critical threshold
+	maxBehind := cfg.Thresholds.SyncStatus.BlocksBehindCritical
+	if warning := cfg.Thresholds.SyncStatus.BlocksBehindWarning; warning > 0 {
+		maxBehind = min(maxBehind, warning)
+	}
+	status.SyncHealthy = status.BlocksBehind() <= int64(maxBehind) && !headStale(cfg, status)
 	
+	// Check the node is on the expected chain and has not been reset
+	status.ChainID = chainID
+	status.ChainHealthy = localHeight >= node.ExpectedMinHeight
+	if chainErr != nil {
+		status.addError(subsystemChain, chainErr)
+	} else {
+		status.ChainHealthy = status.ChainHealthy && (node.ExpectedChainID == "" || chainID == node.ExpectedChainID)
+	}
+
 	// Check peer count
-	peerCount, err := client.GetPeers()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] failed to get peer count: %w", err)
+	if peerErr != nil {
+		status.addError(subsystemPeers, peerErr)
 	}
 	status.PeerCount = peerCount
 	
 	// Check NAT status
-	natStatus, err := client.GetNATStatus()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] failed to get NAT status: %w", err)
+	if natErr != nil {
+		status.addError(subsystemNAT, natErr)
 	}
 	status.NATStatus = natStatus
 	
-	// Check network health
-	status.NetHealthy = peerCount >= cfg.Thresholds.Network.MinPeersHealthy
+	// Peer directions come from the resource manager, so they are optional
+	if peerDetailsErr == nil {
+		status.PeerDetails.Available = true
+		status.PeerDetails.Inbound = peerDetails.Inbound
+		status.PeerDetails.Outbound = peerDetails.Outbound
+	}
+
+	// Check network health, the warning band starts above the healthy minimum
+	status.NetHealthy = peerErr != nil ||
+		(peerCount >= max(cfg.Thresholds.Network.MinPeersHealthy, cfg.Thresholds.Network.MinPeersWarning) && !noInboundPeers(cfg, status))
 	
 	// Check bandwidth stats
-	bandwidthStats, err := client.GetBandwidthStats()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] failed to get bandwidth stats: %w", err)
-	}
-	status.Bandwidth.TotalIn = bandwidthStats.TotalIn
-	status.Bandwidth.TotalOut = bandwidthStats.TotalOut
-	status.Bandwidth.RateIn = bandwidthStats.RateIn
-	status.Bandwidth.RateOut = bandwidthStats.RateOut
+	status.BandwidthHealthy = true
+	if bandwidthErr != nil {
+		status.addError(subsystemBandwidth, bandwidthErr)
+	} else {
+		status.Bandwidth.TotalIn = bandwidthStats.TotalIn
+		status.Bandwidth.TotalOut = bandwidthStats.TotalOut
+		status.Bandwidth.RateIn = bandwidthStats.RateIn
+		status.Bandwidth.RateOut = bandwidthStats.RateOut
+
+		// Check bandwidth health, each direction is only checked with a threshold.
+		// A single low check is tolerated, see Engine.checkBandwidth.
+		minIn, minOut := cfg.Thresholds.Bandwidth.MinRateInBytes, cfg.Thresholds.Bandwidth.MinRateOutBytes
+		status.BandwidthHealthy = (minIn <= 0 || bandwidthStats.RateIn >= float64(minIn)) &&
+			(minOut <= 0 || bandwidthStats.RateOut >= float64(minOut))
+	}
+	
+	// Resource stats need an admin token, so memory is only checked when available
+	status.ResourcesHealthy = true
+	if resourceErr == nil {
+		status.Resources.Available = true
+		status.Resources.MemoryBytes = resourceStats.Memory
+		status.Resources.Conns = resourceStats.NumConns
+		status.Resources.Streams = resourceStats.NumStreams
+		status.Resources.FDs = resourceStats.NumFD
+
+		if maxMemoryMB := cfg.Thresholds.Resources.MaxMemoryMB; maxMemoryMB > 0 {
+			status.ResourcesHealthy = resourceStats.Memory <= int64(maxMemoryMB)*1024*1024
+		}
+	}
+
+	// Check DAS sampling progress if enabled. Bridge nodes do not sample, so
+	// the check only runs on light and full nodes or an undetected type, and
+	// is skipped for nodes that turn out not to serve the DAS module.
+	status.SamplingHealthy = true
+	if maxBehind := samplingMaxBehind(cfg); maxBehind > 0 && status.NodeType != "bridge" {
+		samplingStats, err := client.GetSamplingStats()
+		if err != nil && !rpc.IsUnavailable(err) {
+			status.addError(subsystemSampling, err)
+		}
+		if err == nil {
+			status.Sampling.Available = true
+			status.Sampling.SampledHeight = samplingStats.SampledChainHead
+			status.Sampling.CatchupHead = samplingStats.CatchupHead
+			status.Sampling.NetworkHead = samplingStats.NetworkHead
+			status.Sampling.CatchUpDone = samplingStats.CatchUpDone
+			status.Sampling.IsRunning = samplingStats.IsRunning
+			status.Sampling.Behind = int64(samplingStats.NetworkHead) - int64(samplingStats.SampledChainHead)
+			status.SamplingHealthy = samplingStats.IsRunning && status.Sampling.Behind <= int64(maxBehind)
+		}
+	}
 	
+	// Check free disk space if a data directory is configured
+	status.DiskHealthy = true
+	if node.DataDir != "" {
+		free, percentUsed, err := diskUsage(node.DataDir)
+		if err != nil {
+			status.addError(subsystemDisk, fmt.Errorf("failed to get disk usage: %w", err))
+		} else {
+			status.DiskFreeBytes = free
+			status.DiskPercentUsed = percentUsed
+			status.DiskHealthy = 100-percentUsed >= cfg.Thresholds.Disk.MinFreePercent
+		}
+	}
+
+	// The blob check and store size run on their own intervals and the
+	// node process is read by the engine, see Engine.checkBlob,
+	// Engine.checkStore and Engine.checkProcess
+	status.BlobHealthy = true
+	status.StoreHealthy = true
+	status.ProcessHealthy = true
+
+	// Slow checks are judged on their moving average, see Engine.checkLatency
+	status.CheckDurationMs = time.Since(status.Timestamp).Milliseconds()
+	status.RPCDurationsMs = make(map[string]int64)
+	for name, duration := range client.CallDurations(status.Timestamp) {
+		status.RPCDurationsMs[name] = duration.Milliseconds()
+	}
+	status.LatencyHealthy = true
+
+	// Repeated partial checks are reported, see Engine.checkQueries
+	status.QueriesHealthy = len(status.Errors) == 0
+
 	// Overall health
-	status.Healthy = status.SyncHealthy && status.NetHealthy
+	status.Healthy = status.ChainHealthy && status.SyncHealthy && status.NetHealthy && status.BandwidthHealthy &&
+		status.SamplingHealthy && status.DiskHealthy && status.ResourcesHealthy && status.QueriesHealthy
+	status.Severity = statusSeverity(cfg, status)
 	
 	return status, nil
 }
+
+// peerLevel returns the peer count the network health is based on, the
+// average if the count is smoothed
+func peerLevel(status *Status) float64 {
+	if status.PeerCountSmoothed > 0 {
+		return status.PeerCountSmoothed
+	}
+	return float64(status.PeerCount)
+}
+
+// noInboundPeers reports whether the node has peers but none that dialed it
+func noInboundPeers(cfg *config.Config, status *Status) bool {
+	return cfg.Thresholds.Network.RequireInbound && status.PeerDetails.Available &&
+		status.PeerCount > 0 && status.PeerDetails.Inbound == 0
+}
+
+// headStale reports whether the newest network header is older than
+// thresholds.sync_status.max_head_age_seconds. Local and network heads can
+// match while both are frozen, e.g. when the node lost the network.
+func headStale(cfg *config.Config, status *Status) bool {
+	maxAge := cfg.Thresholds.SyncStatus.MaxHeadAgeSeconds
+	return maxAge > 0 && status.NetworkHeadAge > int64(maxAge)
+}
+
+// samplingMaxBehind returns the sampling lag above which a node is
+// unhealthy, 0 when the sampling check is off. Without max_behind the
+// critical threshold is used.
+func samplingMaxBehind(cfg *config.Config) int {
+	if sampling := cfg.Thresholds.Sampling; sampling.MaxBehind > 0 {
+		return sampling.MaxBehind
+	}
+	return cfg.Thresholds.Sampling.HeadersBehindCritical
+}
+
+// samplingCriticalBehind returns the sampling lag above which the sampling
+// alert is critical
+func samplingCriticalBehind(cfg *config.Config) int {
+	if sampling := cfg.Thresholds.Sampling; sampling.HeadersBehindCritical > 0 {
+		return sampling.HeadersBehindCritical
+	}
+	return 2 * cfg.Thresholds.Sampling.MaxBehind
+}
+
+// statusSeverity grades a status by its worst unhealthy category
+func statusSeverity(cfg *config.Config, status *Status) string {
+	categories := unhealthyCategories(status)
+	if len(categories) == 0 {
+		return SeverityHealthy
+	}
+	for _, category := range categories {
+		if categorySeverity(cfg, category, status) == alert.SeverityCritical {
+			return SeverityCritical
+		}
+	}
+	return SeverityWarning
+}
@@ -0,0 +1,225 @@
+package monitor
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/21state/celestia-watchtower/config"
+	"github.com/21state/celestia-watchtower/rpc"
+)
+
+// fakeClient answers every query after delay, with the set heights, peer
+// count and errors
+type fakeClient struct {
+	delay         time.Duration
+	networkHeight uint64
+	localHeight   uint64
+	peers         int
+	networkErr    error
+	localErr      error
+}
+
+func (f *fakeClient) wait() { time.Sleep(f.delay) }
+
+func (f *fakeClient) GetNodeInfo() (*rpc.NodeInfo, error) {
+	f.wait()
+	return &rpc.NodeInfo{Type: "light", APIVersion: "v0.20.0"}, nil
+}
+
+func (f *fakeClient) GetNetworkHead() (uint64, time.Time, error) {
+	f.wait()
+	return f.networkHeight, time.Now(), f.networkErr
+}
+
+func (f *fakeClient) GetLocalHead() (uint64, error) {
+	f.wait()
+	return f.localHeight, f.localErr
+}
+
+func (f *fakeClient) GetChainID() (string, error) {
+	f.wait()
+	return "mocha-4", nil
+}
+
+func (f *fakeClient) GetPeers() (int, error) {
+	f.wait()
+	return f.peers, nil
+}
+
+func (f *fakeClient) GetPeerDetails() (*rpc.PeerDetails, error) {
+	f.wait()
+	return &rpc.PeerDetails{Inbound: 10, Outbound: 10}, nil
+}
+
+func (f *fakeClient) GetNATStatus() (string, error) {
+	f.wait()
+	return "Public", nil
+}
+
+func (f *fakeClient) GetBandwidthStats() (*rpc.BandwidthStats, error) {
+	f.wait()
+	return &rpc.BandwidthStats{RateIn: 1 << 20, RateOut: 1 << 20}, nil
+}
+
+func (f *fakeClient) GetResourceStats() (*rpc.ResourceStats, error) {
+	f.wait()
+	return &rpc.ResourceStats{}, nil
+}
+
+func (f *fakeClient) GetSamplingStats() (*rpc.SamplingStats, error) {
+	return &rpc.SamplingStats{SampledChainHead: f.localHeight, NetworkHead: f.networkHeight, IsRunning: true}, nil
+}
+
+func (f *fakeClient) CallDurations(since time.Time) map[string]time.Duration {
+	return nil
+}
+
+func testNode() config.NodeConfig {
+	node := config.DefaultNodeConfig()
+	node.Name = "test"
+	node.ExpectedChainID = "mocha-4"
+	return node
+}
+
+func TestCheckNodeStatusQueriesConcurrently(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	client := &fakeClient{delay: delay, networkHeight: 1000, localHeight: 1000, peers: 20}
+
+	start := time.Now()
+	status, err := CheckNodeStatus(client, config.DefaultConfig(), testNode())
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("CheckNodeStatus: %v", err)
+	}
+
+	// Nine queries in sequence would take 900ms
+	if elapsed > 3*delay {
+		t.Errorf("check took %v, want about one call of %v", elapsed, delay)
+	}
+	if status.LocalHeight != 1000 || status.NetworkHeight != 1000 || status.ChainID != "mocha-4" || status.PeerCount != 20 {
+		t.Errorf("status missing query results: %+v", status)
+	}
+	if len(status.Errors) != 0 {
+		t.Errorf("unexpected errors: %v", status.Errors)
+	}
+}
+
+func TestCheckNodeStatusHeightErrors(t *testing.T) {
+	cause := errors.New("connection refused")
+	tests := []struct {
+		name   string
+		client *fakeClient
+		want   string
+	}{
+		{"network", &fakeClient{networkErr: cause, localHeight: 1000}, "failed to get network height"},
+		{"local", &fakeClient{localErr: cause, networkHeight: 1000}, "failed to get local height"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CheckNodeStatus(tt.client, config.DefaultConfig(), testNode())
+			if err == nil {
+				t.Fatal("CheckNodeStatus succeeded, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+			if !errors.Is(err, cause) {
+				t.Errorf("error %q does not wrap the query error", err)
+			}
+		})
+	}
+}
+
+func TestCheckNodeStatusLocalAhead(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := config.DefaultConfig()
+	client := &fakeClient{networkHeight: 1000, localHeight: 1003, peers: 20}
+	status, err := CheckNodeStatus(client, cfg, testNode())
+	if err != nil {
+		t.Fatalf("CheckNodeStatus: %v", err)
+	}
+
+	if status.HeightDiff != -3 {
+		t.Errorf("HeightDiff = %d, want -3", status.HeightDiff)
+	}
+	if behind := status.BlocksBehind(); behind != 0 {
+		t.Errorf("BlocksBehind() = %d, want 0", behind)
+	}
+	if !status.SyncHealthy {
+		t.Error("node ahead of the network head is not sync healthy")
+	}
+	if got := status.HeightSummary(); got != "3 ahead" {
+		t.Errorf("HeightSummary() = %q, want %q", got, "3 ahead")
+	}
+
+	// A lasting lead is only logged, it does not change the health
+	e, err := New(cfg, Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer e.Close()
+	n := e.nodes[0]
+	for i := 0; i < aheadNoteChecks; i++ {
+		e.checkAhead(n, status)
+	}
+	if n.aheadChecks != aheadNoteChecks || !status.SyncHealthy {
+		t.Errorf("after %d checks ahead: aheadChecks = %d, sync healthy = %v", aheadNoteChecks, n.aheadChecks, status.SyncHealthy)
+	}
+}
+
+func TestCheckNodeStatusSeverityBands(t *testing.T) {
+	tests := []struct {
+		name            string
+		behindWarning   int
+		peersWarning    int
+		behind          uint64
+		peers           int
+		wantSyncHealthy bool
+		wantNetHealthy  bool
+		want            string
+	}{
+		{"synced", 0, 0, 0, 20, true, true, SeverityHealthy},
+		{"behind without warning band", 0, 0, 10, 20, true, true, SeverityHealthy},
+		{"behind past critical without warning band", 0, 0, 11, 20, false, true, SeverityCritical},
+		{"behind below warning band", 5, 0, 5, 20, true, true, SeverityHealthy},
+		{"behind in warning band", 5, 0, 6, 20, false, true, SeverityWarning},
+		{"behind at critical in warning band", 5, 0, 10, 20, false, true, SeverityWarning},
+		{"behind past critical with warning band", 5, 0, 11, 20, false, true, SeverityCritical},
+		{"peers at minimum without warning band", 0, 0, 0, 5, true, true, SeverityHealthy},
+		{"peers below minimum without warning band", 0, 0, 0, 4, true, false, SeverityCritical},
+		{"no peers", 0, 0, 0, 0, true, false, SeverityCritical},
+		{"peers above warning band", 0, 10, 0, 10, true, true, SeverityHealthy},
+		{"peers in warning band", 0, 10, 0, 9, true, false, SeverityWarning},
+		{"peers at minimum in warning band", 0, 10, 0, 5, true, false, SeverityWarning},
+		{"peers below minimum with warning band", 0, 10, 0, 4, true, false, SeverityCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Thresholds.SyncStatus.BlocksBehindWarning = tt.behindWarning
+			cfg.Thresholds.SyncStatus.BlocksBehindCritical = 10
+			cfg.Thresholds.Network.MinPeersWarning = tt.peersWarning
+			cfg.Thresholds.Network.MinPeersHealthy = 5
+
+			client := &fakeClient{networkHeight: 1000 + tt.behind, localHeight: 1000, peers: tt.peers}
+			status, err := CheckNodeStatus(client, cfg, testNode())
+			if err != nil {
+				t.Fatalf("CheckNodeStatus: %v", err)
+			}
+
+			if status.SyncHealthy != tt.wantSyncHealthy || status.NetHealthy != tt.wantNetHealthy {
+				t.Errorf("sync healthy = %v, net healthy = %v, want %v and %v", status.SyncHealthy, status.NetHealthy, tt.wantSyncHealthy, tt.wantNetHealthy)
+			}
+			if status.Severity != tt.want {
+				t.Errorf("Severity = %q, want %q", status.Severity, tt.want)
+			}
+			if got := statusSeverity(cfg, status); got != tt.want {
+				t.Errorf("statusSeverity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,15 +2,73 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	openrpc "github.com/celestiaorg/celestia-openrpc"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/filecoin-project/go-jsonrpc"
 )
 
 // Client is a wrapper around the celestia-openrpc client
 type Client struct {
-	client *openrpc.Client
-	ctx    context.Context
+	ctx  context.Context
+	opts Options
+
+	// connMu guards the connection, which a failover replaces while other
+	// calls may be running
+	connMu     sync.RWMutex
+	client     *openrpc.Client
+	close      func()
+	current    int       // index in endpoints of the connected endpoint
+	generation int       // incremented on each switch of endpoint
+	switchedAt time.Time // when the client left the primary endpoint
+
+	// chainID is cached after the first successful fetch
+	chainID string
+
+	endpoints []string // the primary endpoint followed by the fallbacks
+	authToken string
+
+	// durations holds the last duration of each call by name, retries included
+	durationsMu sync.Mutex
+	durations   map[string]callDuration
+
+	// denied holds the permission error of each call the auth token may
+	// not make, which is returned without calling the node again
+	deniedMu sync.Mutex
+	denied   map[string]error
+}
+
+// callDuration is how long a call took and when it finished
+type callDuration struct {
+	duration time.Duration
+	finished time.Time
+}
+
+// Options configures the behaviour of RPC calls
+type Options struct {
+	Retries    int           // Number of retries after the first failed attempt
+	RetryDelay time.Duration // Delay before the first retry, doubled on each attempt
+	Timeout    time.Duration // Timeout for a single attempt, zero disables it
+	TLS        TLSOptions    // TLS settings for https:// endpoints
+
+	// Fallbacks are endpoints of the same node tried in order when the
+	// primary endpoint cannot be reached
+	Fallbacks []string
+	// PrimaryRetry is how long the client stays on a fallback before trying
+	// the primary endpoint again, zero stays until the fallback fails
+	PrimaryRetry time.Duration
+	// OnSwitch is called after the client moves to another endpoint, with
+	// the connection error that caused it or nil on a return to the primary
+	OnSwitch func(from, to string, cause error)
 }
 
 // BandwidthStats represents bandwidth statistics
@@ -21,81 +79,639 @@ type BandwidthStats struct {
 	RateOut  float64 // Bytes out per second
 }
 
+// SamplingStats represents the progress of the node's DASer
+type SamplingStats struct {
+	SampledChainHead uint64 // All headers before this height were sampled
+	CatchupHead      uint64 // All headers before this height were submitted for sampling
+	NetworkHead      uint64 // Most recent header height known to the DASer
+	CatchUpDone      bool   // Whether all known headers are sampled
+	IsRunning        bool   // Whether the DASer is running
+}
+
+// PeerDetail describes the connections to a single peer
+type PeerDetail struct {
+	ID       string   // Peer ID
+	Addrs    []string // Known multiaddresses of the peer
+	Inbound  int      // Connections opened by the peer
+	Outbound int      // Connections opened by the node
+}
+
+// PeerDetails describes the connected peers and their connection directions.
+// The node API does not expose the protocols of each peer.
+type PeerDetails struct {
+	Peers    []PeerDetail
+	Inbound  int // Peers with at least one inbound connection
+	Outbound int // Peers with at least one outbound connection
+}
+
+// ResourceStats represents the system scope of the node's libp2p resource manager
+type ResourceStats struct {
+	Memory     int64 // Bytes of memory reserved by libp2p
+	NumConns   int   // Open connections
+	NumStreams int   // Open streams
+	NumFD      int   // File descriptors in use by libp2p
+}
+
+// NodeInfo describes the node software
+type NodeInfo struct {
+	Type       string // Node type, e.g. "light" or "bridge"
+	APIVersion string // Version of the node API
+}
+
 // NewClient creates a new RPC client
-func NewClient(ctx context.Context, rpcEndpoint, authToken string) (*Client, error) {
+func NewClient(ctx context.Context, rpcEndpoint, authToken string, opts Options) (*Client, error) {
 	// Validate the RPC endpoint
 	if rpcEndpoint == "" {
 		return nil, fmt.Errorf("[ERROR] RPC endpoint cannot be empty")
 	}
 
-	client, err := openrpc.NewClient(ctx, rpcEndpoint, authToken)
+	client, closer, err := dial(ctx, rpcEndpoint, authToken, opts.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] failed to create RPC client: %w", err)
 	}
 
 	return &Client{
-		client: client,
-		ctx:    ctx,
+		client:    client,
+		close:     closer,
+		ctx:       ctx,
+		opts:      opts,
+		endpoints: append([]string{rpcEndpoint}, opts.Fallbacks...),
+		authToken: authToken,
 	}, nil
 }
 
-// GetNetworkHead returns the network head height
-func (c *Client) GetNetworkHead() (uint64, error) {
-	header, err := c.client.Header.NetworkHead(c.ctx)
+// Reconnect closes the connection to the node and opens a new one to the
+// current endpoint
+func (c *Client) Reconnect() error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	client, closer, err := dial(c.ctx, c.endpoints[c.current], c.authToken, c.opts.TLS)
 	if err != nil {
-		return 0, fmt.Errorf("[ERROR] failed to get network head: %w", err)
+		return fmt.Errorf("[ERROR] failed to reconnect RPC client: %w", err)
 	}
 
-	return header.Height(), nil
+	c.replace(client, closer, c.current)
+	return nil
+}
+
+// Endpoint returns the endpoint the client is connected to, the primary
+// one unless the client failed over
+func (c *Client) Endpoint() string {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.endpoints[c.current]
+}
+
+// api returns the current connection
+func (c *Client) api() *openrpc.Client {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.client
+}
+
+// connGeneration returns the generation of the current connection
+func (c *Client) connGeneration() int {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.generation
+}
+
+// replace swaps in a connection to endpoints[index]. c.connMu must be held.
+func (c *Client) replace(client *openrpc.Client, closer func(), index int) {
+	c.close()
+	c.client = client
+	c.close = closer
+	if c.current == 0 && index != 0 {
+		c.switchedAt = time.Now()
+	}
+	c.current = index
+	c.generation++
+	// The endpoint may now be served by a different node
+	c.chainID = ""
+}
+
+// failover moves the client to the next endpoint that can be dialed after
+// a connection error on the connection of the given generation. It reports
+// whether calls should be retried on another connection, which is also
+// the case when a concurrent call already switched.
+func (c *Client) failover(generation int, cause error) bool {
+	if len(c.endpoints) < 2 {
+		return false
+	}
+
+	c.connMu.Lock()
+	if c.generation != generation {
+		c.connMu.Unlock()
+		return true
+	}
+	from := c.current
+	to := -1
+	for i := 1; i < len(c.endpoints); i++ {
+		index := (from + i) % len(c.endpoints)
+		client, closer, err := dial(c.ctx, c.endpoints[index], c.authToken, c.opts.TLS)
+		if err != nil {
+			continue
+		}
+		c.replace(client, closer, index)
+		to = index
+		break
+	}
+	c.connMu.Unlock()
+
+	if to < 0 {
+		return false
+	}
+	if c.opts.OnSwitch != nil {
+		c.opts.OnSwitch(c.endpoints[from], c.endpoints[to], cause)
+	}
+	return true
+}
+
+// returnToPrimary moves a client that failed over back to the primary
+// endpoint once PrimaryRetry has passed. Should the primary still be down,
+// the next call fails over again.
+func (c *Client) returnToPrimary() {
+	if c.opts.PrimaryRetry <= 0 {
+		return
+	}
+
+	c.connMu.Lock()
+	from := c.current
+	if from == 0 || time.Since(c.switchedAt) < c.opts.PrimaryRetry {
+		c.connMu.Unlock()
+		return
+	}
+	client, closer, err := dial(c.ctx, c.endpoints[0], c.authToken, c.opts.TLS)
+	if err != nil {
+		// Wait for another interval before trying again
+		c.switchedAt = time.Now()
+		c.connMu.Unlock()
+		return
+	}
+	c.replace(client, closer, 0)
+	c.connMu.Unlock()
+
+	if c.opts.OnSwitch != nil {
+		c.opts.OnSwitch(c.endpoints[from], c.endpoints[0], nil)
+	}
+}
+
+// IsConnectionError reports whether err was caused by the connection to
+// the node rather than by the node handling the call
+func IsConnectionError(err error) bool {
+	var connErr *jsonrpc.RPCConnectionError
+	if errors.As(err, &connErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// A dropped websocket is reported by message only
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		strings.Contains(err.Error(), "websocket connection closed")
+}
+
+// IsUnavailable reports whether err means the node does not serve the
+// called module, as bridge nodes answer DAS calls with a stub error
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "stubbed") ||
+		(strings.Contains(message, "method '") && strings.Contains(message, "' not found"))
+}
+
+// IsPermissionDenied reports whether err means the auth token lacks the
+// permission the call needs, as admin-only calls answer a read token
+func IsPermissionDenied(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "missing permission")
+}
+
+// withRetry runs call until it succeeds or the retries are exhausted,
+// backing off exponentially between attempts. A connection error moves
+// the client to a fallback endpoint, which is tried at once without
+// using up an attempt. It stops early if the client context is cancelled.
+// A call the token is not permitted to make fails at once, and later
+// calls of it return the same error without reaching the node.
+func withRetry[T any](c *Client, name string, call func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := c.deniedError(name); err != nil {
+		return zero, err
+	}
+
+	start := time.Now()
+	defer func() { c.recordDuration(name, time.Since(start)) }()
+
+	delay := c.opts.RetryDelay
+	attempts := c.opts.Retries + 1
+
+	c.returnToPrimary()
+
+	var err error
+	switches := 0
+	for attempt := 1; attempt <= attempts; attempt++ {
+		generation := c.connGeneration()
+		var result T
+		err = c.callWithTimeout(name, func(ctx context.Context) error {
+			var callErr error
+			result, callErr = call(ctx)
+			return callErr
+		})
+		if err == nil {
+			return result, nil
+		}
+
+		if switches < len(c.endpoints)-1 && c.ctx.Err() == nil && IsConnectionError(err) && c.failover(generation, err) {
+			switches++
+			attempt--
+			continue
+		}
+
+		// Retrying a module the node does not serve, or a call the token
+		// may not make, cannot succeed
+		if IsUnavailable(err) {
+			return zero, err
+		}
+		if IsPermissionDenied(err) {
+			c.setDenied(name, err)
+			return zero, err
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return zero, fmt.Errorf("cancelled after %d attempts: %w", attempt, err)
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return zero, fmt.Errorf("failed after %d attempts: %w", attempts, err)
+}
+
+// deniedError returns the cached permission error of a call, nil if the
+// call is not known to be denied
+func (c *Client) deniedError(name string) error {
+	c.deniedMu.Lock()
+	defer c.deniedMu.Unlock()
+	return c.denied[name]
+}
+
+// setDenied caches the permission error of a call
+func (c *Client) setDenied(name string, err error) {
+	c.deniedMu.Lock()
+	defer c.deniedMu.Unlock()
+
+	if c.denied == nil {
+		c.denied = make(map[string]error)
+	}
+	c.denied[name] = err
+}
+
+// recordDuration stores how long the last call of the given name took
+func (c *Client) recordDuration(name string, duration time.Duration) {
+	c.durationsMu.Lock()
+	defer c.durationsMu.Unlock()
+
+	if c.durations == nil {
+		c.durations = make(map[string]callDuration)
+	}
+	c.durations[name] = callDuration{duration: duration, finished: time.Now()}
+}
+
+// CallDurations returns how long the last call of each RPC method that
+// finished after since took, retries included, keyed by method name such
+// as "header.NetworkHead"
+func (c *Client) CallDurations(since time.Time) map[string]time.Duration {
+	c.durationsMu.Lock()
+	defer c.durationsMu.Unlock()
+
+	durations := make(map[string]time.Duration, len(c.durations))
+	for name, call := range c.durations {
+		if !call.finished.Before(since) {
+			durations[name] = call.duration
+		}
+	}
+	return durations
+}
+
+// callWithTimeout runs a single attempt of call with the configured timeout.
+// A timeout is reported explicitly so it can be told apart from shutdown.
+func (c *Client) callWithTimeout(name string, call func(ctx context.Context) error) error {
+	if c.opts.Timeout <= 0 {
+		return call(c.ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.opts.Timeout)
+	defer cancel()
+
+	err := call(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && c.ctx.Err() == nil {
+		return fmt.Errorf("RPC call %s timed out after %s", name, c.opts.Timeout)
+	}
+
+	return err
+}
+
+// GetNetworkHead returns the network head height and the time of its header
+func (c *Client) GetNetworkHead() (uint64, time.Time, error) {
+	type head struct {
+		height uint64
+		time   time.Time
+	}
+	networkHead, err := withRetry(c, "header.NetworkHead", func(ctx context.Context) (head, error) {
+		header, err := c.api().Header.NetworkHead(ctx)
+		if err != nil {
+			return head{}, err
+		}
+		return head{height: header.Height(), time: header.Time()}, nil
+	})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("[ERROR] failed to get network head: %w", err)
+	}
+
+	return networkHead.height, networkHead.time, nil
+}
+
+// GetChainID returns the chain ID of the node's local head. The chain ID is
+// cached after the first successful call.
+func (c *Client) GetChainID() (string, error) {
+	c.connMu.RLock()
+	cached := c.chainID
+	c.connMu.RUnlock()
+	if cached != "" {
+		return cached, nil
+	}
+
+	chainID, err := withRetry(c, "header.LocalHead", func(ctx context.Context) (string, error) {
+		header, err := c.api().Header.LocalHead(ctx)
+		if err != nil {
+			return "", err
+		}
+		return header.ChainID(), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] failed to get chain ID: %w", err)
+	}
+
+	c.connMu.Lock()
+	c.chainID = chainID
+	c.connMu.Unlock()
+	return chainID, nil
 }
 
 // GetLocalHead returns the local head height
 func (c *Client) GetLocalHead() (uint64, error) {
-	header, err := c.client.Header.LocalHead(c.ctx)
+	height, err := withRetry(c, "header.LocalHead", func(ctx context.Context) (uint64, error) {
+		header, err := c.api().Header.LocalHead(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return header.Height(), nil
+	})
 	if err != nil {
 		return 0, fmt.Errorf("[ERROR] failed to get local head: %w", err)
 	}
 
-	return header.Height(), nil
+	return height, nil
+}
+
+// GetHeaderHashAtHeight returns the hash of the header the node has at
+// the given height, in hex
+func (c *Client) GetHeaderHashAtHeight(height uint64) (string, error) {
+	hash, err := withRetry(c, "header.GetByHeight", func(ctx context.Context) (string, error) {
+		header, err := c.api().Header.GetByHeight(ctx, height)
+		if err != nil {
+			return "", err
+		}
+		return header.Hash().String(), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] failed to get header at height %d: %w", height, err)
+	}
+
+	return hash, nil
 }
 
 // GetPeers returns the number of connected peers
 func (c *Client) GetPeers() (int, error) {
-	peers, err := c.client.P2P.Peers(c.ctx)
+	count, err := withRetry(c, "p2p.Peers", func(ctx context.Context) (int, error) {
+		peers, err := c.api().P2P.Peers(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return len(peers), nil
+	})
 	if err != nil {
 		return 0, fmt.Errorf("[ERROR] failed to get peers: %w", err)
 	}
 
-	return len(peers), nil
+	return count, nil
+}
+
+// GetPeerDetails returns the connected peers with their addresses and
+// connection directions, taken from the libp2p resource manager
+func (c *Client) GetPeerDetails() (*PeerDetails, error) {
+	details, err := withRetry(c, "p2p.ResourceState", func(ctx context.Context) (*PeerDetails, error) {
+		state, err := c.api().P2P.ResourceState(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details := &PeerDetails{}
+		for id, scope := range state.Peers {
+			peer := PeerDetail{
+				ID:       id.String(),
+				Inbound:  scope.NumConnsInbound,
+				Outbound: scope.NumConnsOutbound,
+			}
+			// Peers that only hold streams are not connected
+			if peer.Inbound == 0 && peer.Outbound == 0 {
+				continue
+			}
+
+			// Addresses are informational, a peer that just left has none
+			if info, err := c.api().P2P.PeerInfo(ctx, id); err == nil {
+				for _, addr := range info.Addrs {
+					peer.Addrs = append(peer.Addrs, addr.String())
+				}
+			}
+
+			if peer.Inbound > 0 {
+				details.Inbound++
+			}
+			if peer.Outbound > 0 {
+				details.Outbound++
+			}
+			details.Peers = append(details.Peers, peer)
+		}
+		sort.Slice(details.Peers, func(i, j int) bool { return details.Peers[i].ID < details.Peers[j].ID })
+		return details, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] failed to get peer details: %w", err)
+	}
+
+	return details, nil
 }
 
 // GetNATStatus returns the NAT status as a string
 func (c *Client) GetNATStatus() (string, error) {
-	natStatus, err := c.client.P2P.NATStatus(c.ctx)
+	natStatus, err := withRetry(c, "p2p.NATStatus", func(ctx context.Context) (string, error) {
+		natStatus, err := c.api().P2P.NATStatus(ctx)
+		if err != nil {
+			return "", err
+		}
+		return natStatus.String(), nil
+	})
 	if err != nil {
 		return "", fmt.Errorf("[ERROR] failed to get NAT status: %w", err)
 	}
 
-	return natStatus.String(), nil
+	return natStatus, nil
 }
 
 // GetBandwidthStats returns bandwidth statistics
 func (c *Client) GetBandwidthStats() (*BandwidthStats, error) {
-	stats, err := c.client.P2P.BandwidthStats(c.ctx)
+	stats, err := withRetry(c, "p2p.BandwidthStats", func(ctx context.Context) (*BandwidthStats, error) {
+		stats, err := c.api().P2P.BandwidthStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// The API returns the values directly, no need to parse
+		return &BandwidthStats{
+			TotalIn:  stats.TotalIn,
+			TotalOut: stats.TotalOut,
+			RateIn:   stats.RateIn,
+			RateOut:  stats.RateOut,
+		}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] failed to get bandwidth stats: %w", err)
 	}
 
-	// The API returns the values directly, no need to parse
-	return &BandwidthStats{
-		TotalIn:  stats.TotalIn,
-		TotalOut: stats.TotalOut,
-		RateIn:   stats.RateIn,
-		RateOut:  stats.RateOut,
-	}, nil
+	return stats, nil
+}
+
+// GetSamplingStats returns the DASer sampling progress
+func (c *Client) GetSamplingStats() (*SamplingStats, error) {
+	stats, err := withRetry(c, "das.SamplingStats", func(ctx context.Context) (*SamplingStats, error) {
+		stats, err := c.api().DAS.SamplingStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SamplingStats{
+			SampledChainHead: stats.SampledChainHead,
+			CatchupHead:      stats.CatchupHead,
+			NetworkHead:      stats.NetworkHead,
+			CatchUpDone:      stats.CatchUpDone,
+			IsRunning:        stats.IsRunning,
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] failed to get sampling stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetResourceStats returns the resource usage reported by the node's
+// libp2p resource manager
+func (c *Client) GetResourceStats() (*ResourceStats, error) {
+	stats, err := withRetry(c, "p2p.ResourceState", func(ctx context.Context) (*ResourceStats, error) {
+		state, err := c.api().P2P.ResourceState(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		system := state.System
+		return &ResourceStats{
+			Memory:     system.Memory,
+			NumConns:   system.NumConnsInbound + system.NumConnsOutbound,
+			NumStreams: system.NumStreamsInbound + system.NumStreamsOutbound,
+			NumFD:      system.NumFD,
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] failed to get resource stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetNodeInfo returns the node type and API version
+func (c *Client) GetNodeInfo() (*NodeInfo, error) {
+	info, err := withRetry(c, "node.Info", func(ctx context.Context) (*NodeInfo, error) {
+		info, err := c.api().Node.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &NodeInfo{
+			Type:       nodeTypeName(uint8(info.Type)),
+			APIVersion: info.APIVersion,
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] failed to get node info: %w", err)
+	}
+
+	return info, nil
+}
+
+// nodeTypeName converts a node type as numbered by celestia-node to its name
+func nodeTypeName(nodeType uint8) string {
+	switch nodeType {
+	case 1:
+		return "bridge"
+	case 2:
+		return "full"
+	case 3:
+		return "light"
+	}
+	return fmt.Sprintf("unknown(%d)", nodeType)
+}
+
+// SubmitBlob submits a blob with the given namespace ID and data and returns
+// the height it was included at. It is attempted once, since a retry could
+// pay for the blob twice, and may take up to timeout to be included.
+func (c *Client) SubmitBlob(namespaceID, data []byte, timeout time.Duration) (uint64, error) {
+	namespace, err := share.NewBlobNamespaceV0(namespaceID)
+	if err != nil {
+		return 0, fmt.Errorf("[ERROR] invalid blob namespace: %w", err)
+	}
+	b, err := blob.NewBlobV0(namespace, data)
+	if err != nil {
+		return 0, fmt.Errorf("[ERROR] failed to create blob: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, timeout)
+	defer cancel()
+
+	height, err := c.api().Blob.Submit(ctx, []*blob.Blob{b}, blob.NewSubmitOptions())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && c.ctx.Err() == nil {
+			return 0, fmt.Errorf("[ERROR] blob was not included within %s", timeout)
+		}
+		return 0, fmt.Errorf("[ERROR] failed to submit blob: %w", err)
+	}
+
+	return height, nil
 }
 
 // Close closes the client connection
 func (c *Client) Close() {
-	c.client.Close()
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.close()
 }
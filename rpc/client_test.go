@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetryFailsFastOnPermissionDenied(t *testing.T) {
+	c := &Client{
+		ctx:       context.Background(),
+		opts:      Options{Retries: 3, RetryDelay: 500 * time.Millisecond},
+		endpoints: []string{"http://localhost:26658"},
+	}
+
+	calls := 0
+	denied := errors.New("missing permission to invoke 'Info' (need 'admin')")
+	call := func(ctx context.Context) (int, error) {
+		calls++
+		return 0, denied
+	}
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := withRetry(c, "node.Info", call); !errors.Is(err, denied) {
+			t.Fatalf("withRetry error = %v, want the permission error", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > c.opts.RetryDelay {
+		t.Errorf("withRetry took %v, want no backoff", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("node called %d times, want 1 with the denial cached", calls)
+	}
+
+	// Other calls are unaffected
+	if _, err := withRetry(c, "p2p.Peers", func(ctx context.Context) (int, error) { return 1, nil }); err != nil {
+		t.Errorf("withRetry of another call: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	openrpc "github.com/celestiaorg/celestia-openrpc"
+	"github.com/filecoin-project/go-jsonrpc"
+)
+
+// TLSOptions configures TLS for https:// RPC endpoints
+type TLSOptions struct {
+	CACertPath         string // PEM file with the CA certificates trusted for the node, empty uses the system pool
+	ClientCertPath     string // PEM client certificate for mutual TLS
+	ClientKeyPath      string // PEM private key of the client certificate
+	InsecureSkipVerify bool   // Skip verification of the node certificate
+}
+
+// Enabled reports whether any TLS setting differs from the defaults
+func (o TLSOptions) Enabled() bool {
+	return o.CACertPath != "" || o.ClientCertPath != "" || o.ClientKeyPath != "" || o.InsecureSkipVerify
+}
+
+// tlsConfig builds the TLS configuration described by the options
+func (o TLSOptions) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify}
+
+	if o.CACertPath != "" {
+		pem, err := os.ReadFile(o.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in CA file %s", o.CACertPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.ClientCertPath != "" || o.ClientKeyPath != "" {
+		if o.ClientCertPath == "" || o.ClientKeyPath == "" {
+			return nil, fmt.Errorf("client certificate and key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(o.ClientCertPath, o.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// dial connects to every API namespace of the node and returns the client
+// with a function closing all of its connections. Endpoints with TLS options
+// use an HTTP client built from them, which is only possible over https://
+// since websocket connections always use the default dialer.
+func dial(ctx context.Context, endpoint, authToken string, opts TLSOptions) (*openrpc.Client, func(), error) {
+	if !opts.Enabled() {
+		client, err := openrpc.NewClient(ctx, endpoint, authToken)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, client.Close, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, nil, fmt.Errorf("TLS settings require an https:// endpoint, got %s://", u.Scheme)
+	}
+
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient := &http.Client{Transport: transport}
+
+	var header http.Header
+	if authToken != "" {
+		header = http.Header{openrpc.AuthKey: []string{"Bearer " + authToken}}
+	}
+
+	// Mirror openrpc.NewClient, which cannot take client options
+	var client openrpc.Client
+	modules := map[string]interface{}{
+		"fraud":  &client.Fraud,
+		"blob":   &client.Blob,
+		"header": &client.Header,
+		"state":  &client.State,
+		"share":  &client.Share,
+		"das":    &client.DAS,
+		"p2p":    &client.P2P,
+		"node":   &client.Node,
+		"da":     &client.DA,
+	}
+
+	var closers []jsonrpc.ClientCloser
+	closeAll := func() {
+		for _, closer := range closers {
+			closer()
+		}
+	}
+	for name, module := range modules {
+		closer, err := jsonrpc.NewMergeClient(ctx, endpoint, name, []interface{}{module}, header, jsonrpc.WithHTTPClient(httpClient))
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		closers = append(closers, closer)
+	}
+
+	return &client, closeAll, nil
+}
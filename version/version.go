@@ -0,0 +1,21 @@
+// Package version holds the build information of the watchtower. The
+// variables are set at build time, for example:
+//
+//	go build -ldflags "-X github.com/21state/celestia-watchtower/version.Version=v1.2.0 \
+//	  -X github.com/21state/celestia-watchtower/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/21state/celestia-watchtower/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package version
+
+import "fmt"
+
+// Build information, overridden with -ldflags -X
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// String returns the build information on one line
+func String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", Version, Commit, Date)
+}